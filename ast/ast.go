@@ -2,7 +2,38 @@
 // programming language and provides functionality for traversing the AST.
 package ast
 
+import (
+	"github.com/armsnyder/typescript-ast-go/jsdoc"
+	"github.com/armsnyder/typescript-ast-go/token"
+)
+
 // Node is a common interface that all nodes in the AST implement.
 type Node interface {
+	Pos() token.Pos // position of the first character belonging to the node
+	End() token.Pos // position immediately after the last character of the node
 	node()
 }
+
+// Documented is a [Node] that may have a leading comment. JSDoc parses the
+// leading comment as a JSDoc comment, returning nil if there is none.
+type Documented interface {
+	Node
+	JSDoc() *jsdoc.Comment
+}
+
+// Range is the source range of a [Node]. It is embedded in every node in
+// order to implement the Pos and End methods.
+type Range struct {
+	StartPos token.Pos
+	EndPos   token.Pos
+}
+
+// Pos returns the position of the first character belonging to the node.
+func (r Range) Pos() token.Pos {
+	return r.StartPos
+}
+
+// End returns the position immediately after the last character of the node.
+func (r Range) End() token.Pos {
+	return r.EndPos
+}
@@ -0,0 +1,129 @@
+package ast
+
+import "github.com/armsnyder/typescript-ast-go/jsdoc"
+
+// ClassElement is a [Node] that represents a member of a class.
+type ClassElement interface {
+	Expr
+	classElement()
+}
+
+// PropertyDeclaration is an expression that declares a class property.
+type PropertyDeclaration struct {
+	Range
+
+	Modifiers       ModifierFlags
+	Name            *Identifier
+	QuestionToken   bool
+	Type            Type
+	Initializer     Expr
+	LeadingComment  string
+	TrailingComment string
+}
+
+func (n *PropertyDeclaration) String() string {
+	return n.LeadingComment + " / " + n.TrailingComment
+}
+
+func (n *PropertyDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*PropertyDeclaration) node()         {}
+func (*PropertyDeclaration) expr()         {}
+func (*PropertyDeclaration) classElement() {}
+
+// MethodDeclaration is an expression that declares a class method.
+type MethodDeclaration struct {
+	Range
+
+	Modifiers       ModifierFlags
+	Name            *Identifier
+	QuestionToken   bool
+	TypeParameters  []*TypeParameter
+	Parameters      []*Parameter
+	Type            Type
+	LeadingComment  string
+	TrailingComment string
+}
+
+func (n *MethodDeclaration) String() string {
+	return n.LeadingComment + " / " + n.TrailingComment
+}
+
+func (n *MethodDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*MethodDeclaration) node()         {}
+func (*MethodDeclaration) expr()         {}
+func (*MethodDeclaration) classElement() {}
+
+// Constructor is an expression that declares a class constructor.
+type Constructor struct {
+	Range
+
+	Modifiers       ModifierFlags
+	Parameters      []*Parameter
+	LeadingComment  string
+	TrailingComment string
+}
+
+func (n *Constructor) String() string {
+	return n.LeadingComment + " / " + n.TrailingComment
+}
+
+func (n *Constructor) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*Constructor) node()         {}
+func (*Constructor) expr()         {}
+func (*Constructor) classElement() {}
+
+// GetAccessor is an expression that declares a class property getter.
+type GetAccessor struct {
+	Range
+
+	Modifiers       ModifierFlags
+	Name            *Identifier
+	Parameters      []*Parameter
+	Type            Type
+	LeadingComment  string
+	TrailingComment string
+}
+
+func (n *GetAccessor) String() string {
+	return n.LeadingComment + " / " + n.TrailingComment
+}
+
+func (n *GetAccessor) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*GetAccessor) node()         {}
+func (*GetAccessor) expr()         {}
+func (*GetAccessor) classElement() {}
+
+// SetAccessor is an expression that declares a class property setter.
+type SetAccessor struct {
+	Range
+
+	Modifiers       ModifierFlags
+	Name            *Identifier
+	Parameters      []*Parameter
+	LeadingComment  string
+	TrailingComment string
+}
+
+func (n *SetAccessor) String() string {
+	return n.LeadingComment + " / " + n.TrailingComment
+}
+
+func (n *SetAccessor) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*SetAccessor) node()         {}
+func (*SetAccessor) expr()         {}
+func (*SetAccessor) classElement() {}
@@ -0,0 +1,194 @@
+package ast
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/armsnyder/typescript-ast-go/token"
+)
+
+// Comment is a single // or /* */ comment.
+type Comment struct {
+	Range
+
+	Text string // comment text, including the comment markers
+}
+
+func (*Comment) node() {}
+
+// CommentGroup is a sequence of comments with no other tokens and no empty
+// lines between them.
+type CommentGroup struct {
+	List []*Comment // len(List) > 0
+}
+
+// Pos returns the position of the first character of the first comment.
+func (g *CommentGroup) Pos() token.Pos {
+	return g.List[0].Pos()
+}
+
+// End returns the position immediately after the last comment.
+func (g *CommentGroup) End() token.Pos {
+	return g.List[len(g.List)-1].End()
+}
+
+// Text returns the text of the comment group, without comment markers,
+// leading asterisks of block comment lines, or surrounding blank lines.
+// Lines are separated by a single newline.
+func (g *CommentGroup) Text() string {
+	var lines []string
+	for _, c := range g.List {
+		if text, ok := strings.CutPrefix(c.Text, "//"); ok {
+			lines = append(lines, strings.TrimPrefix(text, " "))
+			continue
+		}
+
+		text := strings.TrimSuffix(strings.TrimPrefix(c.Text, "/*"), "*/")
+		for _, line := range strings.Split(text, "\n") {
+			line = strings.TrimLeft(line, " \t")
+			line = strings.TrimPrefix(strings.TrimLeft(line, "*"), " ")
+			lines = append(lines, line)
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (*CommentGroup) node() {}
+
+// CommentMap maps each AST node to the comment groups associated with it.
+type CommentMap map[Node][]*CommentGroup
+
+// NewCommentMap creates a new comment map by associating each comment group
+// in comments with the nearest node in the AST rooted at node:
+//
+//   - A comment group that begins on the line on which the preceding node
+//     ends is associated with that node, unless the following node begins on
+//     the line on which the comment group ends and is closer to it.
+//   - Otherwise, the comment group is associated with the node that follows
+//     it, if that node is within the innermost node enclosing the comment
+//     group.
+//   - Otherwise, the comment group is associated with the node that precedes
+//     it within the enclosing node, or with the enclosing node itself.
+//
+// Where several nodes start or end at the same position, the outermost is
+// chosen.
+func NewCommentMap(node Node, comments []*CommentGroup) CommentMap {
+	// Nodes are collected in depth-first order, which is ordered by position
+	// with outer nodes before the nodes they contain.
+	var nodes []Node
+	Inspect(node, func(n Node) bool {
+		if n != nil && n.Pos().IsValid() {
+			nodes = append(nodes, n)
+		}
+		return true
+	})
+
+	groups := append([]*CommentGroup(nil), comments...)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Pos().Offset < groups[j].Pos().Offset
+	})
+
+	// The nodes and comment groups are swept in a single pass. stack holds
+	// the nodes enclosing the current position, innermost last, and prev is
+	// the last node to end before it.
+	var stack []Node
+	var prev Node
+	pop := func(offset int) {
+		for len(stack) > 0 && stack[len(stack)-1].End().Offset <= offset {
+			prev = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	cmap := make(CommentMap)
+	i := 0
+	for _, g := range groups {
+		for ; i < len(nodes) && nodes[i].Pos().Offset <= g.Pos().Offset; i++ {
+			pop(nodes[i].Pos().Offset)
+			stack = append(stack, nodes[i])
+		}
+		pop(g.Pos().Offset)
+
+		var next, enclosing Node
+		if i < len(nodes) {
+			next = nodes[i]
+		}
+		if len(stack) > 0 {
+			enclosing = stack[len(stack)-1]
+		}
+
+		n := nearestNode(g, prev, next, enclosing)
+		if n == nil {
+			n = node
+		}
+		cmap[n] = append(cmap[n], g)
+	}
+	return cmap
+}
+
+// nearestNode returns the node with which the comment group g is associated,
+// given the nodes that precede, follow and enclose it, any of which may be
+// nil.
+func nearestNode(g *CommentGroup, prev, next, enclosing Node) Node {
+	isEnclosed := func(n Node) bool {
+		return n != nil && (enclosing == nil || n.Pos().Offset >= enclosing.Pos().Offset && n.End().Offset <= enclosing.End().Offset)
+	}
+
+	// A comment group between two nodes on the same line belongs to the
+	// nearer one, so that a comment following a node as in "string /* c */]"
+	// stays with it, while one preceding a node as in "| /* c */ E" moves
+	// forward.
+	isTrailing := func() bool {
+		if next == nil || next.Pos().Line > g.End().Line {
+			return true
+		}
+		return g.Pos().Offset-prev.End().Offset <= next.Pos().Offset-g.End().Offset
+	}
+
+	switch {
+	case isEnclosed(prev) && prev.End().Line == g.Pos().Line && isTrailing():
+		return prev
+	case isEnclosed(next):
+		return next
+	case isEnclosed(prev):
+		return prev
+	default:
+		return enclosing
+	}
+}
+
+// Comments returns all comment groups in the comment map, sorted by position.
+func (cmap CommentMap) Comments() []*CommentGroup {
+	var comments []*CommentGroup
+	for _, groups := range cmap {
+		comments = append(comments, groups...)
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].Pos().Offset < comments[j].Pos().Offset
+	})
+	return comments
+}
+
+// Filter returns a new comment map consisting of only those entries of cmap
+// for which a corresponding node exists in the AST rooted at node.
+func (cmap CommentMap) Filter(node Node) CommentMap {
+	filtered := make(CommentMap)
+	Inspect(node, func(n Node) bool {
+		if groups := cmap[n]; len(groups) > 0 {
+			filtered[n] = groups
+		}
+		return true
+	})
+	return filtered
+}
@@ -0,0 +1,92 @@
+package ast_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/armsnyder/typescript-ast-go/ast"
+	"github.com/armsnyder/typescript-ast-go/parser"
+)
+
+func TestCommentGroup_Text(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		want     string
+	}{
+		{
+			name:     "line comments",
+			comments: []string{"// a", "//b  ", "//", "// c"},
+			want:     "a\nb\n\nc",
+		},
+		{
+			name:     "block comment",
+			comments: []string{"/**\n * a\n *\n * b\n */"},
+			want:     "a\n\nb",
+		},
+		{
+			name:     "mixed",
+			comments: []string{"/* a */", "// b"},
+			want:     "a\nb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := &ast.CommentGroup{}
+			for _, text := range tt.comments {
+				group.List = append(group.List, &ast.Comment{Text: text})
+			}
+			if got := group.Text(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCommentMap(t *testing.T) {
+	sourceFile := parser.Parse([]byte(`// leading
+type A = string; // trailing
+
+interface B {
+	// member
+	c: string;
+	[key: /* param type */ string]: D | /* union member */ E;
+	[id: string /* ChangeAnnotationIdentifier */]: ChangeAnnotation;
+	// dangling
+}
+
+// end of file`))
+
+	cmap := ast.NewCommentMap(sourceFile, sourceFile.Comments)
+
+	var got []string
+	for node, groups := range cmap {
+		for _, group := range groups {
+			got = append(got, fmt.Sprintf("%s: %T %s", group.Text(), node, node.Pos()))
+		}
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"ChangeAnnotationIdentifier: *ast.Parameter 8:3",
+		"dangling: *ast.IndexSignature 8:2",
+		"end of file: *ast.InterfaceDeclaration 4:1",
+		"leading: *ast.TypeAliasDeclaration 2:1",
+		"member: *ast.PropertySignature 6:2",
+		"param type: *ast.TypeReference 7:25",
+		"trailing: *ast.TypeAliasDeclaration 2:1",
+		"union member: *ast.TypeReference 7:57",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot:\n%s\n\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if got := cmap.Comments(); !reflect.DeepEqual(got, sourceFile.Comments) {
+		t.Errorf("got %d comment groups, want %d", len(got), len(sourceFile.Comments))
+	}
+}
@@ -1,6 +1,15 @@
 package ast
 
-import "github.com/armsnyder/typescript-ast-go/token"
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/armsnyder/typescript-ast-go/jsdoc"
+	"github.com/armsnyder/typescript-ast-go/token"
+)
 
 // Expr is a [Node] that represents an expression. An expression produces a
 // value.
@@ -11,6 +20,8 @@ type Expr interface {
 
 // NumericLiteral is a numeric literal expression.
 type NumericLiteral struct {
+	Range
+
 	Text string
 }
 
@@ -18,12 +29,79 @@ func (n *NumericLiteral) String() string {
 	return n.Text
 }
 
+// Value returns the numeric value of the literal. Values too large to be
+// represented are returned as positive infinity.
+func (n *NumericLiteral) Value() (float64, error) {
+	text := strings.ReplaceAll(n.Text, "_", "")
+	if base, digits := integerBase(text); base != 0 {
+		i, ok := new(big.Int).SetString(digits, base)
+		if !ok {
+			return 0, fmt.Errorf("invalid numeric literal %q", n.Text)
+		}
+		f, _ := new(big.Float).SetInt(i).Float64()
+		return f, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("invalid numeric literal %q", n.Text)
+	}
+	return f, nil
+}
+
 func (*NumericLiteral) node() {}
 func (*NumericLiteral) expr() {}
 
+// BigIntLiteral is a bigint literal expression, such as 10n.
+type BigIntLiteral struct {
+	Range
+
+	Text string // including the trailing n
+}
+
+func (n *BigIntLiteral) String() string {
+	return n.Text
+}
+
+// Value returns the integer value of the literal.
+func (n *BigIntLiteral) Value() (*big.Int, error) {
+	text := strings.ReplaceAll(strings.TrimSuffix(n.Text, "n"), "_", "")
+	base, digits := integerBase(text)
+	if base == 0 {
+		base, digits = 10, text
+	}
+	i, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid bigint literal %q", n.Text)
+	}
+	return i, nil
+}
+
+func (*BigIntLiteral) node() {}
+func (*BigIntLiteral) expr() {}
+
+// integerBase returns the base and digits of a hexadecimal, binary or octal
+// integer literal, or a zero base if the literal has no base prefix.
+func integerBase(text string) (base int, digits string) {
+	if len(text) > 2 && text[0] == '0' {
+		switch text[1] {
+		case 'x', 'X':
+			return 16, text[2:]
+		case 'b', 'B':
+			return 2, text[2:]
+		case 'o', 'O':
+			return 8, text[2:]
+		}
+	}
+	return 0, ""
+}
+
 // StringLiteral is a string literal expression.
 type StringLiteral struct {
-	Text string
+	Range
+
+	Text  string // raw text between the quotes
+	Value string // text with escape sequences decoded
+	Quote byte   // '\'' or '"'
 }
 
 func (n *StringLiteral) String() string {
@@ -33,8 +111,65 @@ func (n *StringLiteral) String() string {
 func (*StringLiteral) node() {}
 func (*StringLiteral) expr() {}
 
+// RegularExpressionLiteral is a regular expression literal expression, such
+// as /ab+c/i.
+type RegularExpressionLiteral struct {
+	Range
+
+	Pattern string // raw text between the slashes
+	Flags   string
+}
+
+func (n *RegularExpressionLiteral) String() string {
+	return "/" + n.Pattern + "/" + n.Flags
+}
+
+func (*RegularExpressionLiteral) node() {}
+func (*RegularExpressionLiteral) expr() {}
+
+// NoSubstitutionTemplateLiteral is a template literal expression without
+// substitutions, such as `abc`.
+type NoSubstitutionTemplateLiteral struct {
+	Range
+
+	Text string // raw text between the backticks
+}
+
+func (n *NoSubstitutionTemplateLiteral) String() string {
+	return n.Text
+}
+
+func (*NoSubstitutionTemplateLiteral) node() {}
+func (*NoSubstitutionTemplateLiteral) expr() {}
+
+// TemplateExpression is a template literal expression with substitutions,
+// such as `a${b}c`.
+type TemplateExpression struct {
+	Range
+
+	Head          string // raw text before the first substitution
+	TemplateSpans []*TemplateSpan
+}
+
+func (*TemplateExpression) node() {}
+func (*TemplateExpression) expr() {}
+
+// TemplateSpan is an expression that represents a substitution in a
+// [TemplateExpression] and the literal text that follows it.
+type TemplateSpan struct {
+	Range
+
+	Expression Expr
+	Literal    string // raw text following the substitution
+}
+
+func (*TemplateSpan) node() {}
+func (*TemplateSpan) expr() {}
+
 // ArrayLiteralExpression is an array literal expression.
 type ArrayLiteralExpression struct {
+	Range
+
 	Elements []Expr
 }
 
@@ -43,6 +178,8 @@ func (*ArrayLiteralExpression) expr() {}
 
 // Identifier is an identifier literal expression.
 type Identifier struct {
+	Range
+
 	Text string
 }
 
@@ -55,7 +192,9 @@ func (*Identifier) expr() {}
 
 // QualifiedName is a qualified name expression.
 type QualifiedName struct {
-	Left  *Identifier
+	Range
+
+	Left  Expr // *Identifier or *QualifiedName
 	Right *Identifier
 }
 
@@ -64,6 +203,8 @@ func (*QualifiedName) expr() {}
 
 // EnumMember is an enum member expression.
 type EnumMember struct {
+	Range
+
 	Name           *Identifier
 	Initializer    Expr
 	LeadingComment string
@@ -73,12 +214,21 @@ func (n *EnumMember) String() string {
 	return n.LeadingComment
 }
 
+func (n *EnumMember) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
 func (*EnumMember) node() {}
 func (*EnumMember) expr() {}
 
 // TypeParameter is a type parameter expression.
 type TypeParameter struct {
-	Name *Identifier
+	Range
+
+	Modifiers  ModifierFlags // ModifierConst, ModifierIn or ModifierOut
+	Name       *Identifier
+	Constraint Type // or nil
+	Default    Type // or nil
 }
 
 func (*TypeParameter) node() {}
@@ -86,6 +236,9 @@ func (*TypeParameter) expr() {}
 
 // HeritageClause is a heritage clause expression.
 type HeritageClause struct {
+	Range
+
+	Token token.Kind // token.Extends or token.Implements
 	Types []*ExpressionWithTypeArguments
 }
 
@@ -94,7 +247,10 @@ func (*HeritageClause) expr() {}
 
 // ExpressionWithTypeArguments is an expression with type arguments.
 type ExpressionWithTypeArguments struct {
-	Expression *Identifier
+	Range
+
+	Expression    *Identifier
+	TypeArguments []Type
 }
 
 func (*ExpressionWithTypeArguments) node() {}
@@ -102,8 +258,14 @@ func (*ExpressionWithTypeArguments) expr() {}
 
 // Parameter is a parameter expression.
 type Parameter struct {
-	Name *Identifier
-	Type Type
+	Range
+
+	Modifiers      ModifierFlags
+	DotDotDotToken bool
+	Name           *Identifier
+	QuestionToken  bool
+	Type           Type
+	Initializer    Expr
 }
 
 func (*Parameter) node() {}
@@ -111,6 +273,8 @@ func (*Parameter) expr() {}
 
 // VariableDeclarationList is an expression that declares a list of variables.
 type VariableDeclarationList struct {
+	Range
+
 	Declarations []*VariableDeclaration
 }
 
@@ -119,6 +283,8 @@ func (*VariableDeclarationList) expr() {}
 
 // VariableDeclaration is an expression that declares a variable.
 type VariableDeclaration struct {
+	Range
+
 	Name        *Identifier
 	Type        Type
 	Initializer Expr
@@ -130,6 +296,8 @@ func (*VariableDeclaration) expr() {}
 // PrefixUnaryExpression is an expression that applies a unary operator to an
 // operand.
 type PrefixUnaryExpression struct {
+	Range
+
 	Operator token.Kind
 	Operand  Expr
 }
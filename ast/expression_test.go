@@ -0,0 +1,76 @@
+package ast_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/armsnyder/typescript-ast-go/ast"
+)
+
+func TestNumericLiteral_Value(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+	}{
+		{text: "0", want: 0},
+		{text: "2147483647", want: 2147483647},
+		{text: "1.5", want: 1.5},
+		{text: ".5", want: 0.5},
+		{text: "1.", want: 1},
+		{text: "1e-3", want: 0.001},
+		{text: "2.5E+10", want: 2.5e10},
+		{text: "1_000_000", want: 1000000},
+		{text: "0x1F", want: 31},
+		{text: "0B1010", want: 10},
+		{text: "0o17", want: 15},
+		{text: "0xFFFF_FFFF_FFFF_FFFF_FFFF", want: 0x1p80},
+		{text: "1e400", want: math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := (&ast.NumericLiteral{Text: tt.text}).Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBigIntLiteral_Value(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "10n", want: "10"},
+		{text: "0xFFn", want: "255"},
+		{text: "0b11n", want: "3"},
+		{text: "0o777n", want: "511"},
+		{text: "123_456_789_012_345_678_901_234_567_890n", want: "123456789012345678901234567890"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := (&ast.BigIntLiteral{Text: tt.text}).Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _ := new(big.Int).SetString(tt.want, 10)
+			if got.Cmp(want) != 0 {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNumericLiteral_Value_Invalid(t *testing.T) {
+	for _, text := range []string{"", "0x", "1e", "abc"} {
+		if _, err := (&ast.NumericLiteral{Text: text}).Value(); err == nil {
+			t.Errorf("%q: expected error", text)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package ast
+
+// NamedImportBindings is a [Node] that represents the bindings of an import
+// clause other than the default import. It is either a [*NamespaceImport] or
+// a [*NamedImports].
+type NamedImportBindings interface {
+	Expr
+	namedImportBindings()
+}
+
+// NamedExportBindings is a [Node] that represents the bindings of an export
+// declaration. It is either a [*NamespaceExport] or a [*NamedExports].
+type NamedExportBindings interface {
+	Expr
+	namedExportBindings()
+}
+
+// ImportClause is an expression that represents the bindings introduced by an
+// import declaration.
+type ImportClause struct {
+	Range
+
+	IsTypeOnly    bool
+	Name          *Identifier         // default import, or nil
+	NamedBindings NamedImportBindings // or nil
+}
+
+func (*ImportClause) node() {}
+func (*ImportClause) expr() {}
+
+// NamespaceImport is an expression that imports an entire module as a
+// namespace, as in `* as ns`.
+type NamespaceImport struct {
+	Range
+
+	Name *Identifier
+}
+
+func (*NamespaceImport) node()                {}
+func (*NamespaceImport) expr()                {}
+func (*NamespaceImport) namedImportBindings() {}
+
+// NamedImports is an expression that imports a list of named bindings, as in
+// `{ A, B as C }`.
+type NamedImports struct {
+	Range
+
+	Elements []*ImportSpecifier
+}
+
+func (*NamedImports) node()                {}
+func (*NamedImports) expr()                {}
+func (*NamedImports) namedImportBindings() {}
+
+// ImportSpecifier is an expression that imports a single named binding.
+type ImportSpecifier struct {
+	Range
+
+	IsTypeOnly   bool
+	PropertyName *Identifier // name in the imported module, if renamed with as
+	Name         *Identifier
+}
+
+func (*ImportSpecifier) node() {}
+func (*ImportSpecifier) expr() {}
+
+// NamespaceExport is an expression that re-exports an entire module as a
+// namespace, as in `* as ns`.
+type NamespaceExport struct {
+	Range
+
+	Name *Identifier
+}
+
+func (*NamespaceExport) node()                {}
+func (*NamespaceExport) expr()                {}
+func (*NamespaceExport) namedExportBindings() {}
+
+// NamedExports is an expression that exports a list of named bindings, as in
+// `{ A, B as C }`.
+type NamedExports struct {
+	Range
+
+	Elements []*ExportSpecifier
+}
+
+func (*NamedExports) node()                {}
+func (*NamedExports) expr()                {}
+func (*NamedExports) namedExportBindings() {}
+
+// ExportSpecifier is an expression that exports a single named binding.
+type ExportSpecifier struct {
+	Range
+
+	IsTypeOnly   bool
+	PropertyName *Identifier // local name, if renamed with as
+	Name         *Identifier
+}
+
+func (*ExportSpecifier) node() {}
+func (*ExportSpecifier) expr() {}
@@ -0,0 +1,50 @@
+package ast
+
+import "strings"
+
+// ModifierFlags is a set of modifier keywords that apply to a declaration.
+type ModifierFlags uint
+
+const (
+	ModifierExport    ModifierFlags = 1 << iota // export
+	ModifierDefault                             // default
+	ModifierDeclare                             // declare
+	ModifierPublic                              // public
+	ModifierPrivate                             // private
+	ModifierProtected                           // protected
+	ModifierAbstract                            // abstract
+	ModifierStatic                              // static
+	ModifierOverride                            // override
+	ModifierReadonly                            // readonly
+	ModifierConst                               // const
+	ModifierIn                                  // in
+	ModifierOut                                 // out
+)
+
+var modifierKeywords = [...]string{
+	"export",
+	"default",
+	"declare",
+	"public",
+	"private",
+	"protected",
+	"abstract",
+	"static",
+	"override",
+	"readonly",
+	"const",
+	"in",
+	"out",
+}
+
+// String returns the modifier keywords in the set, separated by spaces, in
+// the order that TypeScript requires them to appear.
+func (m ModifierFlags) String() string {
+	var keywords []string
+	for i, keyword := range modifierKeywords {
+		if m&(1<<i) != 0 {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return strings.Join(keywords, " ")
+}
@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/armsnyder/typescript-ast-go/jsdoc"
+
 // Signature is a [Node] that represents a signature. A signature defines a
 // property.
 type Signature interface {
@@ -9,6 +11,9 @@ type Signature interface {
 
 // PropertySignature is an expression that defines an object property.
 type PropertySignature struct {
+	Range
+
+	Modifiers       ModifierFlags // ModifierReadonly
 	Name            *Identifier
 	QuestionToken   bool
 	Type            Type
@@ -20,12 +25,19 @@ func (n *PropertySignature) String() string {
 	return n.LeadingComment + " / " + n.TrailingComment
 }
 
+func (n *PropertySignature) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
 func (*PropertySignature) node()      {}
 func (*PropertySignature) expr()      {}
 func (*PropertySignature) signature() {}
 
 // IndexSignature is an expression that defines an object index signature.
 type IndexSignature struct {
+	Range
+
+	Modifiers      ModifierFlags
 	Parameters     []*Parameter
 	Type           Type
 	LeadingComment string
@@ -35,6 +47,83 @@ func (n *IndexSignature) String() string {
 	return n.LeadingComment
 }
 
-func (*IndexSignature) node()      {}
-func (*IndexSignature) expr()      {}
-func (*IndexSignature) signature() {}
+func (n *IndexSignature) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*IndexSignature) node()         {}
+func (*IndexSignature) expr()         {}
+func (*IndexSignature) signature()    {}
+func (*IndexSignature) classElement() {}
+
+// MethodSignature is an expression that defines an object method.
+type MethodSignature struct {
+	Range
+
+	Name            *Identifier
+	QuestionToken   bool
+	TypeParameters  []*TypeParameter
+	Parameters      []*Parameter
+	Type            Type
+	LeadingComment  string
+	TrailingComment string
+}
+
+func (n *MethodSignature) String() string {
+	return n.LeadingComment + " / " + n.TrailingComment
+}
+
+func (n *MethodSignature) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*MethodSignature) node()      {}
+func (*MethodSignature) expr()      {}
+func (*MethodSignature) signature() {}
+
+// CallSignature is an expression that defines how an object can be called.
+type CallSignature struct {
+	Range
+
+	TypeParameters  []*TypeParameter
+	Parameters      []*Parameter
+	Type            Type
+	LeadingComment  string
+	TrailingComment string
+}
+
+func (n *CallSignature) String() string {
+	return n.LeadingComment + " / " + n.TrailingComment
+}
+
+func (n *CallSignature) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*CallSignature) node()      {}
+func (*CallSignature) expr()      {}
+func (*CallSignature) signature() {}
+
+// ConstructSignature is an expression that defines how an object can be
+// constructed with the new operator.
+type ConstructSignature struct {
+	Range
+
+	TypeParameters  []*TypeParameter
+	Parameters      []*Parameter
+	Type            Type
+	LeadingComment  string
+	TrailingComment string
+}
+
+func (n *ConstructSignature) String() string {
+	return n.LeadingComment + " / " + n.TrailingComment
+}
+
+func (n *ConstructSignature) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*ConstructSignature) node()      {}
+func (*ConstructSignature) expr()      {}
+func (*ConstructSignature) signature() {}
@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/armsnyder/typescript-ast-go/jsdoc"
+
 // Stmt is a [Node] that represents a statement. A statement performs an
 // action.
 type Stmt interface {
@@ -7,9 +9,21 @@ type Stmt interface {
 	stmt()
 }
 
+// BadStmt is a placeholder for a statement containing syntax errors for which
+// a correct statement node cannot be created.
+type BadStmt struct {
+	Range
+}
+
+func (*BadStmt) node() {}
+func (*BadStmt) stmt() {}
+
 // SourceFile is a statement that represents a source file.
 type SourceFile struct {
+	Range
+
 	Statements []Stmt
+	Comments   []*CommentGroup // all comments in the source file, in order
 }
 
 func (*SourceFile) node() {}
@@ -18,6 +32,8 @@ func (*SourceFile) stmt() {}
 // ModuleBlock is a statement that represents a block of statements in a
 // module.
 type ModuleBlock struct {
+	Range
+
 	Statements []Stmt
 }
 
@@ -26,6 +42,9 @@ func (*ModuleBlock) stmt() {}
 
 // VariableStatement is a statement that declares a variable.
 type VariableStatement struct {
+	Range
+
+	Modifiers       ModifierFlags
 	DeclarationList *VariableDeclarationList
 	LeadingComment  string
 }
@@ -34,12 +53,20 @@ func (n *VariableStatement) String() string {
 	return n.LeadingComment
 }
 
+func (n *VariableStatement) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
 func (*VariableStatement) node() {}
 func (*VariableStatement) stmt() {}
 
 // TypeAliasDeclaration is a statement that introduces a new type alias.
 type TypeAliasDeclaration struct {
+	Range
+
+	Modifiers      ModifierFlags
 	Name           *Identifier
+	TypeParameters []*TypeParameter
 	Type           Type
 	LeadingComment string
 }
@@ -48,11 +75,18 @@ func (n *TypeAliasDeclaration) String() string {
 	return n.LeadingComment
 }
 
+func (n *TypeAliasDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
 func (*TypeAliasDeclaration) node() {}
 func (*TypeAliasDeclaration) stmt() {}
 
 // EnumDeclaration is a statement that introduces a new enum.
 type EnumDeclaration struct {
+	Range
+
+	Modifiers      ModifierFlags
 	Name           *Identifier
 	Members        []*EnumMember
 	LeadingComment string
@@ -62,11 +96,18 @@ func (n *EnumDeclaration) String() string {
 	return n.LeadingComment
 }
 
+func (n *EnumDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
 func (*EnumDeclaration) node() {}
 func (*EnumDeclaration) stmt() {}
 
 // InterfaceDeclaration is a statement that introduces a new interface.
 type InterfaceDeclaration struct {
+	Range
+
+	Modifiers       ModifierFlags
 	Name            *Identifier
 	TypeParameters  []*TypeParameter
 	HeritageClauses []*HeritageClause
@@ -78,14 +119,136 @@ func (n *InterfaceDeclaration) String() string {
 	return n.LeadingComment
 }
 
+func (n *InterfaceDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
 func (*InterfaceDeclaration) node() {}
 func (*InterfaceDeclaration) stmt() {}
 
+// FunctionDeclaration is a statement that declares a function signature.
+// Overloads are represented as separate declarations with the same name.
+type FunctionDeclaration struct {
+	Range
+
+	Modifiers      ModifierFlags
+	Name           *Identifier // nil in an anonymous default export
+	TypeParameters []*TypeParameter
+	Parameters     []*Parameter
+	Type           Type
+	LeadingComment string
+}
+
+func (n *FunctionDeclaration) String() string {
+	return n.LeadingComment
+}
+
+func (n *FunctionDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*FunctionDeclaration) node() {}
+func (*FunctionDeclaration) stmt() {}
+
+// ClassDeclaration is a statement that introduces a new class.
+type ClassDeclaration struct {
+	Range
+
+	Modifiers       ModifierFlags
+	Name            *Identifier // nil in an anonymous default export
+	TypeParameters  []*TypeParameter
+	HeritageClauses []*HeritageClause
+	Members         []ClassElement
+	LeadingComment  string
+}
+
+func (n *ClassDeclaration) String() string {
+	return n.LeadingComment
+}
+
+func (n *ClassDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*ClassDeclaration) node() {}
+func (*ClassDeclaration) stmt() {}
+
+// ModuleDeclaration is a statement that introduces a new namespace.
 type ModuleDeclaration struct {
+	Range
+
+	Modifiers      ModifierFlags
 	Name           *Identifier
 	Body           *ModuleBlock
 	LeadingComment string
 }
 
+func (n *ModuleDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
 func (*ModuleDeclaration) node() {}
 func (*ModuleDeclaration) stmt() {}
+
+// ImportDeclaration is a statement that imports bindings from another module.
+type ImportDeclaration struct {
+	Range
+
+	ImportClause    *ImportClause // nil for an import of the module's side effects only
+	ModuleSpecifier *StringLiteral
+	LeadingComment  string
+}
+
+func (n *ImportDeclaration) String() string {
+	return n.LeadingComment
+}
+
+func (n *ImportDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*ImportDeclaration) node() {}
+func (*ImportDeclaration) stmt() {}
+
+// ExportDeclaration is a statement that exports bindings declared elsewhere,
+// such as `export { A, B as C }` or `export * from 'mod'`.
+type ExportDeclaration struct {
+	Range
+
+	IsTypeOnly      bool
+	ExportClause    NamedExportBindings // nil for `export *`
+	ModuleSpecifier *StringLiteral      // nil unless re-exporting from another module
+	LeadingComment  string
+}
+
+func (n *ExportDeclaration) String() string {
+	return n.LeadingComment
+}
+
+func (n *ExportDeclaration) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*ExportDeclaration) node() {}
+func (*ExportDeclaration) stmt() {}
+
+// ExportAssignment is a statement that exports an expression, either as the
+// default export (`export default x`) or as the entire module (`export = x`).
+type ExportAssignment struct {
+	Range
+
+	IsExportEquals bool
+	Expression     Expr
+	LeadingComment string
+}
+
+func (n *ExportAssignment) String() string {
+	return n.LeadingComment
+}
+
+func (n *ExportAssignment) JSDoc() *jsdoc.Comment {
+	return jsdoc.Parse(n.LeadingComment)
+}
+
+func (*ExportAssignment) node() {}
+func (*ExportAssignment) stmt() {}
@@ -1,5 +1,7 @@
 package ast
 
+import "github.com/armsnyder/typescript-ast-go/token"
+
 // Type is a [Node] that represents a type expression. A type expression is a
 // specific [Expr] that represents a type.
 type Type interface {
@@ -7,8 +9,20 @@ type Type interface {
 	typ()
 }
 
+// BadType is a placeholder for a type containing syntax errors for which a
+// correct type node cannot be created.
+type BadType struct {
+	Range
+}
+
+func (*BadType) node() {}
+func (*BadType) expr() {}
+func (*BadType) typ()  {}
+
 // LiteralType is a literal type expression.
 type LiteralType struct {
+	Range
+
 	Literal Expr
 }
 
@@ -18,6 +32,8 @@ func (*LiteralType) typ()  {}
 
 // TypeLiteral is a type literal expression.
 type TypeLiteral struct {
+	Range
+
 	Members []Signature
 }
 
@@ -27,6 +43,8 @@ func (*TypeLiteral) typ()  {}
 
 // ArrayType is an array type expression.
 type ArrayType struct {
+	Range
+
 	ElementType Expr
 }
 
@@ -34,9 +52,25 @@ func (*ArrayType) node() {}
 func (*ArrayType) expr() {}
 func (*ArrayType) typ()  {}
 
+// IndexedAccessType is a type expression that looks up the type of a
+// property of another type, such as T['name'] or T[number].
+type IndexedAccessType struct {
+	Range
+
+	ObjectType Type
+	IndexType  Type
+}
+
+func (*IndexedAccessType) node() {}
+func (*IndexedAccessType) expr() {}
+func (*IndexedAccessType) typ()  {}
+
 // TypeReference is a type reference expression.
 type TypeReference struct {
-	TypeName Expr
+	Range
+
+	TypeName      Expr
+	TypeArguments []Type
 }
 
 func (*TypeReference) node() {}
@@ -45,6 +79,8 @@ func (*TypeReference) typ()  {}
 
 // UnionType is a union type expression.
 type UnionType struct {
+	Range
+
 	Types []Type
 }
 
@@ -52,8 +88,115 @@ func (*UnionType) node() {}
 func (*UnionType) expr() {}
 func (*UnionType) typ()  {}
 
+// IntersectionType is an intersection type expression, such as A & B.
+type IntersectionType struct {
+	Range
+
+	Types []Type
+}
+
+func (*IntersectionType) node() {}
+func (*IntersectionType) expr() {}
+func (*IntersectionType) typ()  {}
+
+// ConditionalType is a conditional type expression, such as
+// T extends U ? X : Y.
+type ConditionalType struct {
+	Range
+
+	CheckType   Type
+	ExtendsType Type
+	TrueType    Type
+	FalseType   Type
+}
+
+func (*ConditionalType) node() {}
+func (*ConditionalType) expr() {}
+func (*ConditionalType) typ()  {}
+
+// InferType is a type expression that declares a type variable to be
+// inferred in the extends clause of a conditional type, such as infer U.
+type InferType struct {
+	Range
+
+	TypeParameter *TypeParameter
+}
+
+func (*InferType) node() {}
+func (*InferType) expr() {}
+func (*InferType) typ()  {}
+
+// MappedType is a mapped type expression, such as
+// { readonly [K in keyof T]?: T[K] }.
+type MappedType struct {
+	Range
+
+	ReadonlyToken token.Kind     // token.Readonly, token.Plus or token.Minus; 0 if absent
+	TypeParameter *TypeParameter // the key type variable, constrained by the type after in
+	NameType      Type           // the type after as, or nil
+	QuestionToken token.Kind     // token.Question, token.Plus or token.Minus; 0 if absent
+	Type          Type           // or nil
+}
+
+func (*MappedType) node() {}
+func (*MappedType) expr() {}
+func (*MappedType) typ()  {}
+
+// TypeOperator is a type expression that applies a keyword operator to a
+// type, such as keyof T, unique symbol or readonly string[].
+type TypeOperator struct {
+	Range
+
+	Operator token.Kind // token.Keyof, token.Unique or token.Readonly
+	Type     Type
+}
+
+func (*TypeOperator) node() {}
+func (*TypeOperator) expr() {}
+func (*TypeOperator) typ()  {}
+
+// TypeQuery is a type expression that refers to the type of a value, such as
+// typeof someConst.
+type TypeQuery struct {
+	Range
+
+	ExprName      Expr // *Identifier or *QualifiedName
+	TypeArguments []Type
+}
+
+func (*TypeQuery) node() {}
+func (*TypeQuery) expr() {}
+func (*TypeQuery) typ()  {}
+
+// TemplateLiteralType is a template literal type expression, such as
+// `on${Capitalize<Name>}`.
+type TemplateLiteralType struct {
+	Range
+
+	Head          string // raw text before the first substitution
+	TemplateSpans []*TemplateLiteralTypeSpan
+}
+
+func (*TemplateLiteralType) node() {}
+func (*TemplateLiteralType) expr() {}
+func (*TemplateLiteralType) typ()  {}
+
+// TemplateLiteralTypeSpan is an expression that represents a substitution in
+// a [TemplateLiteralType] and the literal text that follows it.
+type TemplateLiteralTypeSpan struct {
+	Range
+
+	Type    Type
+	Literal string // raw text following the substitution
+}
+
+func (*TemplateLiteralTypeSpan) node() {}
+func (*TemplateLiteralTypeSpan) expr() {}
+
 // TupleType is a tuple type expression.
 type TupleType struct {
+	Range
+
 	Elements []Type
 }
 
@@ -61,12 +204,38 @@ func (*TupleType) node() {}
 func (*TupleType) expr() {}
 func (*TupleType) typ()  {}
 
+// RestType is a rest element of a [TupleType], such as ...string[].
+type RestType struct {
+	Range
+
+	Type Type
+}
+
+func (*RestType) node() {}
+func (*RestType) expr() {}
+func (*RestType) typ()  {}
+
 // ParenthesizedType is an expression that wraps another expression in
 // parentheses.
 type ParenthesizedType struct {
+	Range
+
 	Type Type
 }
 
 func (*ParenthesizedType) node() {}
 func (*ParenthesizedType) expr() {}
 func (*ParenthesizedType) typ()  {}
+
+// FunctionType is a function type expression, such as (a: string) => void.
+type FunctionType struct {
+	Range
+
+	TypeParameters []*TypeParameter
+	Parameters     []*Parameter
+	Type           Type
+}
+
+func (*FunctionType) node() {}
+func (*FunctionType) expr() {}
+func (*FunctionType) typ()  {}
@@ -24,8 +24,21 @@ func Walk(v Visitor, node Node) { //nolint:revive // cyclomatic
 	}
 
 	switch n := node.(type) {
+	// Comments.
+	case *Comment:
+	case *CommentGroup:
+		for _, c := range n.List {
+			Walk(w, c)
+		}
+
 	// Expressions.
-	case *NumericLiteral, *StringLiteral, *Identifier:
+	case *NumericLiteral, *BigIntLiteral, *StringLiteral, *RegularExpressionLiteral, *Identifier, *NoSubstitutionTemplateLiteral:
+	case *TemplateExpression:
+		for _, span := range n.TemplateSpans {
+			Walk(w, span)
+		}
+	case *TemplateSpan:
+		Walk(w, n.Expression)
 	case *QualifiedName:
 		Walk(w, n.Left)
 		Walk(w, n.Right)
@@ -35,15 +48,26 @@ func Walk(v Visitor, node Node) { //nolint:revive // cyclomatic
 		}
 	case *EnumMember:
 		Walk(w, n.Name)
-		Walk(w, n.Initializer)
+		if n.Initializer != nil {
+			Walk(w, n.Initializer)
+		}
 	case *TypeParameter:
 		Walk(w, n.Name)
+		if n.Constraint != nil {
+			Walk(w, n.Constraint)
+		}
+		if n.Default != nil {
+			Walk(w, n.Default)
+		}
 	case *HeritageClause:
 		for _, typ := range n.Types {
 			Walk(w, typ)
 		}
 	case *ExpressionWithTypeArguments:
 		Walk(w, n.Expression)
+		for _, typ := range n.TypeArguments {
+			Walk(w, typ)
+		}
 	case *PropertySignature:
 		Walk(w, n.Name)
 		Walk(w, n.Type)
@@ -52,21 +76,127 @@ func Walk(v Visitor, node Node) { //nolint:revive // cyclomatic
 			Walk(w, param)
 		}
 		Walk(w, n.Type)
+	case *MethodSignature:
+		Walk(w, n.Name)
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+	case *CallSignature:
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+	case *ConstructSignature:
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+	case *PropertyDeclaration:
+		Walk(w, n.Name)
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+		if n.Initializer != nil {
+			Walk(w, n.Initializer)
+		}
+	case *MethodDeclaration:
+		Walk(w, n.Name)
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+	case *Constructor:
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
+	case *GetAccessor:
+		Walk(w, n.Name)
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+	case *SetAccessor:
+		Walk(w, n.Name)
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
 	case *Parameter:
 		Walk(w, n.Name)
-		Walk(w, n.Type)
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+		if n.Initializer != nil {
+			Walk(w, n.Initializer)
+		}
 	case *VariableDeclarationList:
 		for _, decl := range n.Declarations {
 			Walk(w, decl)
 		}
 	case *VariableDeclaration:
 		Walk(w, n.Name)
-		Walk(w, n.Type)
-		Walk(w, n.Initializer)
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+		if n.Initializer != nil {
+			Walk(w, n.Initializer)
+		}
 	case *PrefixUnaryExpression:
 		Walk(w, n.Operand)
+	case *ImportClause:
+		if n.Name != nil {
+			Walk(w, n.Name)
+		}
+		if n.NamedBindings != nil {
+			Walk(w, n.NamedBindings)
+		}
+	case *NamespaceImport:
+		Walk(w, n.Name)
+	case *NamedImports:
+		for _, elem := range n.Elements {
+			Walk(w, elem)
+		}
+	case *ImportSpecifier:
+		if n.PropertyName != nil {
+			Walk(w, n.PropertyName)
+		}
+		Walk(w, n.Name)
+	case *NamespaceExport:
+		Walk(w, n.Name)
+	case *NamedExports:
+		for _, elem := range n.Elements {
+			Walk(w, elem)
+		}
+	case *ExportSpecifier:
+		if n.PropertyName != nil {
+			Walk(w, n.PropertyName)
+		}
+		Walk(w, n.Name)
 
 	// Types.
+	case *BadType:
 	case *LiteralType:
 		Walk(w, n.Literal)
 	case *TypeLiteral:
@@ -75,20 +205,69 @@ func Walk(v Visitor, node Node) { //nolint:revive // cyclomatic
 		}
 	case *ArrayType:
 		Walk(w, n.ElementType)
+	case *IndexedAccessType:
+		Walk(w, n.ObjectType)
+		Walk(w, n.IndexType)
 	case *TypeReference:
 		Walk(w, n.TypeName)
+		for _, typ := range n.TypeArguments {
+			Walk(w, typ)
+		}
 	case *UnionType:
 		for _, typ := range n.Types {
 			Walk(w, typ)
 		}
+	case *IntersectionType:
+		for _, typ := range n.Types {
+			Walk(w, typ)
+		}
+	case *ConditionalType:
+		Walk(w, n.CheckType)
+		Walk(w, n.ExtendsType)
+		Walk(w, n.TrueType)
+		Walk(w, n.FalseType)
+	case *InferType:
+		Walk(w, n.TypeParameter)
+	case *MappedType:
+		Walk(w, n.TypeParameter)
+		if n.NameType != nil {
+			Walk(w, n.NameType)
+		}
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
+	case *TypeOperator:
+		Walk(w, n.Type)
+	case *TypeQuery:
+		Walk(w, n.ExprName)
+		for _, typ := range n.TypeArguments {
+			Walk(w, typ)
+		}
+	case *TemplateLiteralType:
+		for _, span := range n.TemplateSpans {
+			Walk(w, span)
+		}
+	case *TemplateLiteralTypeSpan:
+		Walk(w, n.Type)
 	case *TupleType:
 		for _, elem := range n.Elements {
 			Walk(w, elem)
 		}
+	case *RestType:
+		Walk(w, n.Type)
 	case *ParenthesizedType:
 		Walk(w, n.Type)
+	case *FunctionType:
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
+		Walk(w, n.Type)
 
 	// Statements.
+	case *BadStmt:
 	case *SourceFile:
 		for _, stmt := range n.Statements {
 			Walk(w, stmt)
@@ -101,6 +280,9 @@ func Walk(v Visitor, node Node) { //nolint:revive // cyclomatic
 		Walk(w, n.DeclarationList)
 	case *TypeAliasDeclaration:
 		Walk(w, n.Name)
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
 		Walk(w, n.Type)
 	case *EnumDeclaration:
 		Walk(w, n.Name)
@@ -109,12 +291,58 @@ func Walk(v Visitor, node Node) { //nolint:revive // cyclomatic
 		}
 	case *InterfaceDeclaration:
 		Walk(w, n.Name)
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
+		for _, clause := range n.HeritageClauses {
+			Walk(w, clause)
+		}
+		for _, member := range n.Members {
+			Walk(w, member)
+		}
+	case *ClassDeclaration:
+		if n.Name != nil {
+			Walk(w, n.Name)
+		}
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
+		for _, clause := range n.HeritageClauses {
+			Walk(w, clause)
+		}
 		for _, member := range n.Members {
 			Walk(w, member)
 		}
+	case *FunctionDeclaration:
+		if n.Name != nil {
+			Walk(w, n.Name)
+		}
+		for _, param := range n.TypeParameters {
+			Walk(w, param)
+		}
+		for _, param := range n.Parameters {
+			Walk(w, param)
+		}
+		if n.Type != nil {
+			Walk(w, n.Type)
+		}
 	case *ModuleDeclaration:
 		Walk(w, n.Name)
 		Walk(w, n.Body)
+	case *ImportDeclaration:
+		if n.ImportClause != nil {
+			Walk(w, n.ImportClause)
+		}
+		Walk(w, n.ModuleSpecifier)
+	case *ExportDeclaration:
+		if n.ExportClause != nil {
+			Walk(w, n.ExportClause)
+		}
+		if n.ModuleSpecifier != nil {
+			Walk(w, n.ModuleSpecifier)
+		}
+	case *ExportAssignment:
+		Walk(w, n.Expression)
 
 	default:
 		panic(fmt.Sprintf("unknown node type %T", n))
@@ -24,6 +24,8 @@ func Example() {
 	// Visited *ast.SourceFile
 	// Visited *ast.InterfaceDeclaration
 	// Visited *ast.Identifier
+	// Visited *ast.TypeParameter
+	// Visited *ast.Identifier
 	// Visited *ast.PropertySignature
 	// Visited *ast.Identifier
 	// Visited *ast.TypeReference
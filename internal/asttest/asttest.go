@@ -0,0 +1,38 @@
+// Package asttest provides helpers for testing code that produces AST nodes.
+package asttest
+
+import (
+	"reflect"
+
+	"github.com/armsnyder/typescript-ast-go/ast"
+)
+
+// ClearPositions zeroes the source range of every node reachable from v, so
+// that trees can be compared without regard to positions.
+func ClearPositions(v any) {
+	clearPositions(reflect.ValueOf(v))
+}
+
+func clearPositions(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			clearPositions(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			clearPositions(v.Index(i))
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(ast.Range{}) {
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				clearPositions(v.Field(i))
+			}
+		}
+	default:
+	}
+}
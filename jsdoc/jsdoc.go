@@ -0,0 +1,188 @@
+// Package jsdoc parses JSDoc comments, such as the leading comments of
+// declarations in the AST, into their summary and tags.
+package jsdoc
+
+import "strings"
+
+// Comment is a parsed JSDoc comment.
+//
+// Text fields hold the text of the comment as written, with surrounding
+// whitespace trimmed. Inline tags such as {@link X} are left in the text and
+// are also collected in Links.
+type Comment struct {
+	Summary      string   // text before the first block tag
+	Params       []*Param // @param tags
+	Returns      string   // text of the @returns or @return tag
+	Since        string   // text of the @since tag
+	Deprecated   string   // text of the @deprecated tag
+	IsDeprecated bool     // whether there is a @deprecated tag, which may have no text
+	See          []string // text of each @see tag
+	Proposed     bool     // whether there is a @proposed tag
+	Tags         []*Tag   // every block tag, in source order
+	Links        []*Link  // every {@link}, {@linkcode} and {@linkplain} inline tag, in source order
+}
+
+// Tag is a block tag, such as @since 3.17.0.
+type Tag struct {
+	Name string // name without the @, such as "since"
+	Text string
+}
+
+// Param is a @param tag, such as @param uri The document's URI.
+type Param struct {
+	Name string
+	Text string // description, without any leading hyphen
+}
+
+// Link is an inline link tag, such as {@link Position} or
+// {@link Position the position}.
+type Link struct {
+	Tag    string // "link", "linkcode" or "linkplain"
+	Target string // name or URL being linked to
+	Text   string // optional link text
+}
+
+// Parse parses the text of a comment, without its comment markers or leading
+// asterisks, as a JSDoc comment. It returns nil if text is empty.
+func Parse(text string) *Comment {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	c := &Comment{Links: parseLinks(text)}
+
+	var summary []string
+	var tag *Tag
+	var tagLines []string
+	inFence := false
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+
+		switch {
+		case !inFence && isBlockTag(trimmed):
+			c.addTag(tag, tagLines)
+			name := trimmed[1:]
+			i := 0
+			for i < len(name) && isTagNameChar(name[i]) {
+				i++
+			}
+			tag = &Tag{Name: name[:i]}
+			tagLines = []string{name[i:]}
+		case tag == nil:
+			summary = append(summary, line)
+		default:
+			tagLines = append(tagLines, line)
+		}
+	}
+	c.addTag(tag, tagLines)
+
+	c.Summary = strings.TrimSpace(strings.Join(summary, "\n"))
+	return c
+}
+
+// addTag adds a block tag with the given lines of text to the comment. It
+// does nothing if tag is nil.
+func (c *Comment) addTag(tag *Tag, lines []string) {
+	if tag == nil {
+		return
+	}
+	tag.Text = strings.TrimSpace(strings.Join(lines, "\n"))
+	c.Tags = append(c.Tags, tag)
+
+	switch tag.Name {
+	case "param":
+		c.Params = append(c.Params, parseParam(tag.Text))
+	case "returns", "return":
+		c.Returns = tag.Text
+	case "since":
+		c.Since = tag.Text
+	case "deprecated":
+		c.Deprecated = tag.Text
+		c.IsDeprecated = true
+	case "see":
+		c.See = append(c.See, tag.Text)
+	case "proposed":
+		c.Proposed = true
+	default:
+	}
+}
+
+// isBlockTag reports whether s begins with a block tag, such as @since.
+func isBlockTag(s string) bool {
+	return len(s) > 1 && s[0] == '@' && isTagNameChar(s[1])
+}
+
+func isTagNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// parseParam parses the text of a @param tag, which may include a type in
+// braces and an optional name in brackets.
+func parseParam(text string) *Param {
+	if strings.HasPrefix(text, "{") {
+		if end := strings.IndexByte(text, '}'); end != -1 {
+			text = strings.TrimSpace(text[end+1:])
+		}
+	}
+
+	name, desc := cutSpace(text)
+	if strings.HasPrefix(name, "[") {
+		name = strings.TrimSuffix(strings.TrimPrefix(name, "["), "]")
+		name, _, _ = strings.Cut(name, "=")
+	}
+	desc = strings.TrimSpace(strings.TrimPrefix(desc, "-"))
+
+	return &Param{Name: name, Text: desc}
+}
+
+// parseLinks returns the inline link tags in text.
+func parseLinks(text string) []*Link {
+	var links []*Link
+	for {
+		i := strings.Index(text, "{@link")
+		if i == -1 {
+			return links
+		}
+		text = text[i+len("{@"):]
+
+		end := strings.IndexByte(text, '}')
+		if end == -1 {
+			return links
+		}
+		name, body := cutSpace(text[:end])
+		text = text[end+1:]
+		if name != "link" && name != "linkcode" && name != "linkplain" {
+			continue
+		}
+
+		link := &Link{Tag: name}
+		link.Target, link.Text = cutLinkTarget(body)
+		links = append(links, link)
+	}
+}
+
+// cutLinkTarget splits the body of an inline link tag into its target and
+// text, which may be separated by whitespace or a pipe.
+func cutLinkTarget(body string) (target, text string) {
+	end := strings.IndexAny(body, " \t\n|")
+	if end == -1 {
+		return body, ""
+	}
+	text = strings.TrimSpace(body[end:])
+	text = strings.TrimSpace(strings.TrimPrefix(text, "|"))
+	return body[:end], text
+}
+
+// cutSpace splits text around its first run of whitespace.
+func cutSpace(text string) (before, after string) {
+	i := strings.IndexAny(text, " \t\n")
+	if i == -1 {
+		return text, ""
+	}
+	return text[:i], strings.TrimSpace(text[i:])
+}
@@ -0,0 +1,23 @@
+package jsdoc_test
+
+import (
+	"fmt"
+
+	"github.com/armsnyder/typescript-ast-go/ast"
+	"github.com/armsnyder/typescript-ast-go/parser"
+)
+
+func Example() {
+	src := []byte(`
+		/**
+		 * LSP arrays.
+		 *
+		 * @since 3.17.0
+		 */
+		export type LSPArray = LSPAny[];`)
+	sourceFile := parser.Parse(src)
+
+	doc := sourceFile.Statements[0].(ast.Documented).JSDoc()
+	fmt.Printf("%s (since %s)", doc.Summary, doc.Since)
+	// Output: LSP arrays. (since 3.17.0)
+}
@@ -0,0 +1,118 @@
+package jsdoc_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/armsnyder/typescript-ast-go/jsdoc"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want *jsdoc.Comment
+	}{
+		{
+			name: "empty",
+			text: " \n ",
+			want: nil,
+		},
+		{
+			name: "summary only",
+			text: "The document's URI.",
+			want: &jsdoc.Comment{Summary: "The document's URI."},
+		},
+		{
+			name: "since",
+			text: "LSP arrays.\n\n@since 3.17.0",
+			want: &jsdoc.Comment{
+				Summary: "LSP arrays.",
+				Since:   "3.17.0",
+				Tags:    []*jsdoc.Tag{{Name: "since", Text: "3.17.0"}},
+			},
+		},
+		{
+			name: "multiline tag",
+			text: "A text edit.\n\n@since 3.16.0 - support for AnnotatedTextEdit. This is guarded by the\nclient capability.",
+			want: &jsdoc.Comment{
+				Summary: "A text edit.",
+				Since:   "3.16.0 - support for AnnotatedTextEdit. This is guarded by the\nclient capability.",
+				Tags: []*jsdoc.Tag{
+					{Name: "since", Text: "3.16.0 - support for AnnotatedTextEdit. This is guarded by the\nclient capability."},
+				},
+			},
+		},
+		{
+			name: "deprecated without summary",
+			text: "@deprecated use jsonrpcReservedErrorRangeStart",
+			want: &jsdoc.Comment{
+				Deprecated:   "use jsonrpcReservedErrorRangeStart",
+				IsDeprecated: true,
+				Tags:         []*jsdoc.Tag{{Name: "deprecated", Text: "use jsonrpcReservedErrorRangeStart"}},
+			},
+		},
+		{
+			name: "bare tags",
+			text: "Proposed.\n@proposed\n@deprecated",
+			want: &jsdoc.Comment{
+				Summary:      "Proposed.",
+				IsDeprecated: true,
+				Proposed:     true,
+				Tags:         []*jsdoc.Tag{{Name: "proposed"}, {Name: "deprecated"}},
+			},
+		},
+		{
+			name: "params and returns",
+			text: "Sums.\n@param a - the first\n@param {number} [b=1] the second\n@returns the sum\n@see add\n@see sum",
+			want: &jsdoc.Comment{
+				Summary: "Sums.",
+				Params: []*jsdoc.Param{
+					{Name: "a", Text: "the first"},
+					{Name: "b", Text: "the second"},
+				},
+				Returns: "the sum",
+				See:     []string{"add", "sum"},
+				Tags: []*jsdoc.Tag{
+					{Name: "param", Text: "a - the first"},
+					{Name: "param", Text: "{number} [b=1] the second"},
+					{Name: "returns", Text: "the sum"},
+					{Name: "see", Text: "add"},
+					{Name: "see", Text: "sum"},
+				},
+			},
+		},
+		{
+			name: "inline links",
+			text: "See {@link Position} and {@linkcode Range | the range}.\n@see {@link https://example.com docs}",
+			want: &jsdoc.Comment{
+				Summary: "See {@link Position} and {@linkcode Range | the range}.",
+				See:     []string{"{@link https://example.com docs}"},
+				Tags:    []*jsdoc.Tag{{Name: "see", Text: "{@link https://example.com docs}"}},
+				Links: []*jsdoc.Link{
+					{Tag: "link", Target: "Position"},
+					{Tag: "linkcode", Target: "Range", Text: "the range"},
+					{Tag: "link", Target: "https://example.com", Text: "docs"},
+				},
+			},
+		},
+		{
+			name: "code fence",
+			text: "Example:\n```ts\n@decorator\nclass A {}\n```\n@since 1.0",
+			want: &jsdoc.Comment{
+				Summary: "Example:\n```ts\n@decorator\nclass A {}\n```",
+				Since:   "1.0",
+				Tags:    []*jsdoc.Tag{{Name: "since", Text: "1.0"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := jsdoc.Parse(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\ngot:\n%+v\nwant:\n%+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/armsnyder/typescript-ast-go/token"
+)
+
+// Error is a syntax error encountered while parsing. It records the offending
+// token, the token kinds that would have been accepted in its place, and the
+// location of the offending token in the source.
+type Error struct {
+	Pos      token.Pos    // position of the offending token
+	Token    token.Token  // offending token
+	Expected []token.Kind // accepted token kinds, if known
+	Msg      string       // description of the error
+}
+
+func (e *Error) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// ErrorList is a list of syntax errors. The zero value is an empty list ready
+// to use.
+type ErrorList []*Error
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	default:
+		return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+	}
+}
+
+// Err returns an error equivalent to this error list. If the list is empty,
+// Err returns nil.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Unwrap returns the errors in the list, so that [errors.As] can be used to
+// extract an individual [*Error].
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+	return errs
+}
+
+// bailout is the panic value used to abort parsing on the first error.
+type bailout struct {
+	err *Error
+}
+
+func newError(tok token.Token, expected []token.Kind) *Error {
+	return &Error{
+		Pos:      tok.Pos,
+		Token:    tok,
+		Expected: expected,
+		Msg:      errorMessage(tok, expected),
+	}
+}
+
+func errorMessage(tok token.Token, expected []token.Kind) string {
+	got := describeToken(tok)
+
+	switch len(expected) {
+	case 0:
+		return "unexpected " + got
+	case 1:
+		return fmt.Sprintf("expected %s, got %s", describeKind(expected[0]), got)
+	default:
+		kinds := make([]string, len(expected))
+		for i, kind := range expected {
+			kinds[i] = describeKind(kind)
+		}
+		return fmt.Sprintf("expected one of %s, got %s", strings.Join(kinds, ", "), got)
+	}
+}
+
+func describeToken(tok token.Token) string {
+	if tok.Kind.IsLiteral() {
+		return fmt.Sprintf("%s %q", tok.Kind, tok.Text)
+	}
+	if tok.Kind.IsKeyword() {
+		return fmt.Sprintf("keyword %q", tok.Text)
+	}
+	return tok.Kind.String()
+}
+
+// describeKind describes an expected token kind, quoting keywords so that
+// they are not mistaken for other parts of the error message.
+func describeKind(kind token.Kind) string {
+	if kind.IsKeyword() {
+		return strconv.Quote(kind.String())
+	}
+	return kind.String()
+}
@@ -1,72 +1,472 @@
-// Package parser provides a [Parse] function for parsing TypeScript source
-// files into an abstract syntax tree (AST).
+// Package parser provides the [ParseFile] and [Parse] functions for parsing
+// TypeScript source files into an abstract syntax tree (AST).
 package parser
 
 import (
-	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/armsnyder/typescript-ast-go/ast"
+	"github.com/armsnyder/typescript-ast-go/scanner"
 	"github.com/armsnyder/typescript-ast-go/token"
 )
 
+// A Mode value is a set of flags (or 0). They control optional parser
+// functionality.
+type Mode uint
+
+const (
+	// RecoverErrors makes the parser continue after a syntax error. The error
+	// is recorded, the offending statement or type is replaced by an
+	// [ast.BadStmt] or [ast.BadType], and parsing resumes at the next
+	// statement.
+	RecoverErrors Mode = 1 << iota
+)
+
+// ParseFile parses TypeScript source code into an [ast.SourceFile]. If the
+// source code contains a syntax error, ParseFile returns a nil file and an
+// [ErrorList] describing the error.
+//
+// If mode includes [RecoverErrors], ParseFile instead returns a file
+// containing placeholder nodes for the invalid source code, along with an
+// [ErrorList] of every syntax error encountered.
+func ParseFile(source []byte, mode Mode) (sourceFile *ast.SourceFile, err error) {
+	p := parser{mode: mode}
+	p.lex = &lexer{Source: source, Err: p.scanError}
+
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bailout)
+			if !ok {
+				panic(r)
+			}
+			sourceFile = nil
+			err = ErrorList{b.err}
+		}
+	}()
+
+	sourceFile = p.parseSourceFile()
+
+	// A statement that is rescanned after a syntax error reports the errors
+	// in it after the statement-level error.
+	sort.SliceStable(p.errors, func(i, j int) bool {
+		return p.errors[i].Pos.Offset < p.errors[j].Pos.Offset
+	})
+	return sourceFile, p.errors.Err()
+}
+
+// Parse is like [ParseFile] but panics if the source code cannot be parsed.
 func Parse(source []byte) *ast.SourceFile {
-	p := parser{lex: &lexer{Source: source}}
-	return p.parseSourceFile()
+	sourceFile, err := ParseFile(source, 0)
+	if err != nil {
+		panic(err)
+	}
+	return sourceFile
 }
 
 type parser struct {
 	lex             *lexer
+	mode            Mode
+	errors          ErrorList
 	tok             token.Token
+	prevEnd         token.Pos
 	lastComment     string
 	lastLineComment string
+
+	// comments holds every comment read so far, and commentGroupStarts the
+	// index in comments of the first comment of each comment group. A
+	// comment joins the current group if no other token precedes it since
+	// lastCommentTok and it begins on the same or the following line.
+	comments           []*ast.Comment
+	commentGroupStarts []int
+	lastCommentTok     token.Token
+	isCommentGroupOpen bool
+
+	// disallowConditionalTypes is set while parsing the extends clause of a
+	// conditional type, where an unparenthesized extends keyword belongs to
+	// the enclosing conditional type.
+	disallowConditionalTypes bool
 }
 
 func (p *parser) parseSourceFile() *ast.SourceFile {
 	sourceFile := &ast.SourceFile{}
+	p.advance()
+	for p.tok.Kind != token.EOF {
+		sourceFile.Statements = append(sourceFile.Statements, p.parseStatementOrRecover())
+	}
+	sourceFile.Range = ast.Range{StartPos: token.Pos{Line: 1, Column: 1}, EndPos: p.tok.End}
+	sourceFile.Comments = p.commentGroups()
+	return sourceFile
+}
 
-	for {
-		p.advance()
-		if p.tok.Kind == token.EOF {
-			return sourceFile
+// parseStatementOrRecover parses a statement. In RecoverErrors mode, a syntax
+// error inside the statement is recorded, and the statement is replaced by an
+// [ast.BadStmt] that spans to the next synchronization point.
+func (p *parser) parseStatementOrRecover() (stmt ast.Stmt) {
+	if p.mode&RecoverErrors == 0 {
+		return p.parseStatement()
+	}
+
+	saved, savedLex := *p, *p.lex
+
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bailout)
+			if !ok {
+				panic(r)
+			}
+			// Errors recorded by type recovery inside the statement are
+			// superseded by the statement-level error.
+			*p, *p.lex = saved, savedLex
+			p.errors = append(p.errors, b.err)
+			stmt = p.parseBadStmt()
 		}
-		sourceFile.Statements = append(sourceFile.Statements, p.parseStatement())
+	}()
+
+	return p.parseStatement()
+}
+
+// parseBadStmt skips from the start of a statement to the next
+// synchronization point: past the next semicolon, or up to the next closing
+// brace or statement keyword that is not nested inside braces. Parentheses
+// and square brackets are not tracked, so that an unclosed one does not hide
+// the statements that follow it.
+func (p *parser) parseBadStmt() *ast.BadStmt {
+	start := p.tok.Pos
+	depth := 0
+	afterModifier := false
+
+	for first := true; p.tok.Kind != token.EOF; first = false {
+		switch p.tok.Kind {
+		case token.LBrace:
+			depth++
+		case token.RBrace:
+			if depth == 0 && !first {
+				return &ast.BadStmt{Range: p.rangeFrom(start)}
+			}
+			if depth > 0 {
+				depth--
+			}
+		case token.Semicolon:
+			if depth == 0 {
+				p.advance()
+				return &ast.BadStmt{Range: p.rangeFrom(start)}
+			}
+		default:
+			if depth == 0 && !first && !afterModifier && p.isStartOfStatement() {
+				return &ast.BadStmt{Range: p.rangeFrom(start)}
+			}
+		}
+		afterModifier = isModifierKeyword(p.tok.Kind)
+		p.advance()
+	}
+
+	return &ast.BadStmt{Range: p.rangeFrom(start)}
+}
+
+// isStartOfStatement reports whether the current token is a keyword that
+// begins a statement. A contextual keyword begins a statement only if a name
+// follows it on the same line, so that the type in `var type = 1` is not
+// mistaken for a type alias.
+func (p *parser) isStartOfStatement() bool {
+	if !isStatementKeyword(p.tok.Kind) {
+		return false
+	}
+	return !p.tok.Kind.IsContextualKeyword() || p.lookahead(p.isFollowedByNameOnSameLine)
+}
+
+// isFollowedByNameOnSameLine reports whether the token after the current one
+// is an identifier or keyword on the same line.
+func (p *parser) isFollowedByNameOnSameLine() bool {
+	p.advance()
+	return p.isIdentifierName() && !p.hasPrecedingLineBreak()
+}
+
+// isStatementKeyword reports whether kind is a keyword that can begin a
+// statement accepted by parseStatement.
+func isStatementKeyword(kind token.Kind) bool {
+	switch kind {
+	case token.Abstract, token.Class, token.Const, token.Declare, token.Enum, token.Export, token.Function,
+		token.Import, token.Interface, token.Namespace, token.Type:
+		return true
+	default:
+		return false
+	}
+}
+
+// isModifierKeyword reports whether kind is a keyword that can precede the
+// keyword of a top-level statement.
+func isModifierKeyword(kind token.Kind) bool {
+	switch kind {
+	case token.Abstract, token.Declare, token.Default, token.Export:
+		return true
+	default:
+		return false
 	}
 }
 
 func (p *parser) parseStatement() ast.Stmt {
-	p.expect(token.Ident)
+	start := p.tok.Pos
+	if !p.isIdentifierName() {
+		p.errorExpected(token.Ident)
+	}
+	var modifiers ast.ModifierFlags
 	for {
-		switch p.tok.Text {
-		case "export":
+		switch p.tok.Kind {
+		case token.Import:
+			if modifiers == 0 {
+				return p.parseImportDeclaration(start)
+			}
+			p.errorUnexpected()
+		case token.Export:
+			if modifiers != 0 {
+				p.errorUnexpected()
+			}
+			modifiers |= ast.ModifierExport
+			p.advance()
+			switch {
+			case p.tok.Kind == token.LBrace, p.tok.Kind == token.Star:
+				return p.parseExportDeclaration(start)
+			case p.tok.Kind == token.Type && p.lookahead(p.isFollowedByExportClause):
+				return p.parseExportDeclaration(start)
+			case p.tok.Kind == token.Assign:
+				return p.parseExportAssignment(start)
+			default:
+			}
+		case token.Default:
+			if modifiers != ast.ModifierExport {
+				p.errorUnexpected()
+			}
+			modifiers |= ast.ModifierDefault
+			p.advance()
+			if !isDefaultableKeyword(p.tok.Kind) {
+				return p.parseExportAssignment(start)
+			}
+		case token.Declare:
+			modifiers |= ast.ModifierDeclare
+			p.advance()
+		case token.Abstract:
+			modifiers |= ast.ModifierAbstract
 			p.advance()
-		case "const":
-			return p.parseVariableStatement()
-		case "type":
-			return p.parseTypeAliasDeclaration()
-		case "enum":
-			return p.parseEnumDeclaration()
-		case "interface":
-			return p.parseInterfaceDeclaration()
-		case "namespace":
-			return p.parseModuleDeclaration()
+		case token.Const:
+			return p.parseVariableStatement(start, modifiers)
+		case token.Type:
+			return p.parseTypeAliasDeclaration(start, modifiers)
+		case token.Enum:
+			return p.parseEnumDeclaration(start, modifiers)
+		case token.Interface:
+			return p.parseInterfaceDeclaration(start, modifiers)
+		case token.Namespace:
+			return p.parseModuleDeclaration(start, modifiers)
+		case token.Function:
+			return p.parseFunctionDeclaration(start, modifiers)
+		case token.Class:
+			return p.parseClassDeclaration(start, modifiers)
 		default:
-			panic(fmt.Sprintf("unexpected token %s", p.tok))
+			p.errorUnexpected()
 		}
 	}
 }
 
-func (p *parser) parseVariableStatement() *ast.VariableStatement {
-	p.eat(token.Ident)
-	decl := &ast.VariableStatement{LeadingComment: p.consumeComment()}
+// isDefaultableKeyword reports whether kind is a keyword that can begin a
+// declaration following `export default`.
+func isDefaultableKeyword(kind token.Kind) bool {
+	switch kind {
+	case token.Abstract, token.Class, token.Function, token.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *parser) parseImportDeclaration(start token.Pos) *ast.ImportDeclaration {
+	p.eat(token.Import)
+	decl := &ast.ImportDeclaration{LeadingComment: p.consumeComment()}
+	if p.tok.Kind != token.String {
+		decl.ImportClause = p.parseImportClause()
+		p.eat(token.From)
+	}
+	decl.ModuleSpecifier = p.parseStringLiteral()
+	p.eat(token.Semicolon)
+	decl.Range = p.rangeFrom(start)
+	return decl
+}
+
+func (p *parser) parseImportClause() *ast.ImportClause {
+	start := p.tok.Pos
+	clause := &ast.ImportClause{}
+	if p.tok.Kind == token.Type && p.lookahead(p.isFollowedByImportClause) {
+		clause.IsTypeOnly = true
+		p.advance()
+	}
+	if p.isIdentifier() {
+		clause.Name = p.parseIdentifier()
+		if p.tok.Kind != token.Comma {
+			clause.Range = p.rangeFrom(start)
+			return clause
+		}
+		p.advance()
+	}
+	switch p.tok.Kind {
+	case token.Star:
+		bindingStart := p.tok.Pos
+		p.advance()
+		p.eat(token.As)
+		clause.NamedBindings = &ast.NamespaceImport{Name: p.parseIdentifier(), Range: p.rangeFrom(bindingStart)}
+	case token.LBrace:
+		clause.NamedBindings = p.parseNamedImports()
+	default:
+		p.errorExpected(token.Ident, token.Star, token.LBrace)
+	}
+	clause.Range = p.rangeFrom(start)
+	return clause
+}
+
+// isFollowedByImportClause reports whether the token after the current one
+// can begin an import clause, which distinguishes `import type X from` from a
+// default import named type.
+func (p *parser) isFollowedByImportClause() bool {
+	p.advance()
+	switch p.tok.Kind {
+	case token.Star, token.LBrace:
+		return true
+	case token.From:
+		return false
+	default:
+		return p.isIdentifier()
+	}
+}
+
+func (p *parser) parseNamedImports() *ast.NamedImports {
+	start := p.tok.Pos
+	p.eat(token.LBrace)
+	imports := &ast.NamedImports{}
+	for p.tok.Kind != token.RBrace {
+		elemStart := p.tok.Pos
+		elem := &ast.ImportSpecifier{}
+		elem.IsTypeOnly, elem.PropertyName, elem.Name = p.parseImportOrExportSpecifier()
+		elem.Range = p.rangeFrom(elemStart)
+		imports.Elements = append(imports.Elements, elem)
+		if p.tok.Kind != token.Comma {
+			break
+		}
+		p.advance()
+	}
+	p.eat(token.RBrace)
+	imports.Range = p.rangeFrom(start)
+	return imports
+}
+
+func (p *parser) parseExportDeclaration(start token.Pos) *ast.ExportDeclaration {
+	decl := &ast.ExportDeclaration{LeadingComment: p.consumeComment()}
+	if p.tok.Kind == token.Type {
+		decl.IsTypeOnly = true
+		p.advance()
+	}
+	if p.tok.Kind == token.Star {
+		clauseStart := p.tok.Pos
+		p.advance()
+		if p.tok.Kind == token.As {
+			p.advance()
+			decl.ExportClause = &ast.NamespaceExport{Name: p.parseIdentifierName(), Range: p.rangeFrom(clauseStart)}
+		}
+		p.eat(token.From)
+		decl.ModuleSpecifier = p.parseStringLiteral()
+	} else {
+		decl.ExportClause = p.parseNamedExports()
+		if p.tok.Kind == token.From {
+			p.advance()
+			decl.ModuleSpecifier = p.parseStringLiteral()
+		}
+	}
+	p.eat(token.Semicolon)
+	decl.Range = p.rangeFrom(start)
+	return decl
+}
+
+// isFollowedByExportClause reports whether the token after the current one
+// begins the clause of an export declaration, which distinguishes
+// `export type { X }` from a type alias declaration.
+func (p *parser) isFollowedByExportClause() bool {
+	p.advance()
+	return p.tok.Kind == token.LBrace || p.tok.Kind == token.Star
+}
+
+func (p *parser) parseNamedExports() *ast.NamedExports {
+	start := p.tok.Pos
+	p.eat(token.LBrace)
+	exports := &ast.NamedExports{}
+	for p.tok.Kind != token.RBrace {
+		elemStart := p.tok.Pos
+		elem := &ast.ExportSpecifier{}
+		elem.IsTypeOnly, elem.PropertyName, elem.Name = p.parseImportOrExportSpecifier()
+		elem.Range = p.rangeFrom(elemStart)
+		exports.Elements = append(exports.Elements, elem)
+		if p.tok.Kind != token.Comma {
+			break
+		}
+		p.advance()
+	}
+	p.eat(token.RBrace)
+	exports.Range = p.rangeFrom(start)
+	return exports
+}
+
+// parseImportOrExportSpecifier parses an element of a named import or export
+// list, such as `A`, `type A` or `A as B`.
+func (p *parser) parseImportOrExportSpecifier() (isTypeOnly bool, propertyName, name *ast.Identifier) {
+	if p.tok.Kind == token.Type && p.lookahead(p.isFollowedBySpecifierName) {
+		isTypeOnly = true
+		p.advance()
+	}
+	name = p.parseIdentifierName()
+	if p.tok.Kind == token.As {
+		p.advance()
+		propertyName, name = name, p.parseIdentifierName()
+	}
+	return isTypeOnly, propertyName, name
+}
+
+// isFollowedBySpecifierName reports whether the token after the current one
+// is the name in an import or export specifier, which distinguishes
+// `type A` from a specifier named type. A following as is the name in
+// `type as` and `type as as B`, but not in `type as B`.
+func (p *parser) isFollowedBySpecifierName() bool {
+	p.advance()
+	if p.tok.Kind != token.As {
+		return p.isIdentifierName()
+	}
+	p.advance()
+	return p.tok.Kind == token.As || p.tok.Kind == token.RBrace || p.tok.Kind == token.Comma
+}
+
+func (p *parser) parseExportAssignment(start token.Pos) *ast.ExportAssignment {
+	decl := &ast.ExportAssignment{LeadingComment: p.consumeComment()}
+	if p.tok.Kind == token.Assign {
+		decl.IsExportEquals = true
+		p.advance()
+	}
+	decl.Expression = p.parseInitializer()
+	p.eat(token.Semicolon)
+	decl.Range = p.rangeFrom(start)
+	return decl
+}
+
+func (p *parser) parseVariableStatement(start token.Pos, modifiers ast.ModifierFlags) *ast.VariableStatement {
+	p.eat(token.Const)
+	decl := &ast.VariableStatement{Modifiers: modifiers, LeadingComment: p.consumeComment()}
 	decl.DeclarationList = p.parseVariableDeclarationList()
+	decl.Range = p.rangeFrom(start)
 	return decl
 }
 
 func (p *parser) parseVariableDeclarationList() *ast.VariableDeclarationList {
+	start := p.tok.Pos
 	decl := &ast.VariableDeclarationList{}
 	for {
 		decl.Declarations = append(decl.Declarations, p.parseVariableDeclaration())
 		if p.tok.Kind != token.Comma {
+			decl.Range = p.rangeFrom(start)
 			p.eat(token.Semicolon)
 			return decl
 		}
@@ -75,6 +475,7 @@ func (p *parser) parseVariableDeclarationList() *ast.VariableDeclarationList {
 }
 
 func (p *parser) parseVariableDeclaration() *ast.VariableDeclaration {
+	start := p.tok.Pos
 	decl := &ast.VariableDeclaration{}
 	decl.Name = p.parseIdentifier()
 	if p.tok.Kind == token.Colon {
@@ -85,36 +486,266 @@ func (p *parser) parseVariableDeclaration() *ast.VariableDeclaration {
 		p.advance()
 		decl.Initializer = p.parseInitializer()
 	}
+	decl.Range = p.rangeFrom(start)
 	return decl
 }
 
-func (p *parser) parseModuleDeclaration() *ast.ModuleDeclaration {
-	p.eat(token.Ident)
-	decl := &ast.ModuleDeclaration{LeadingComment: p.consumeComment()}
-	decl.Name = p.parseIdentifier()
+func (p *parser) parseFunctionDeclaration(start token.Pos, modifiers ast.ModifierFlags) *ast.FunctionDeclaration {
+	p.eat(token.Function)
+	decl := &ast.FunctionDeclaration{Modifiers: modifiers, LeadingComment: p.consumeComment()}
+	if p.isIdentifier() || modifiers&ast.ModifierDefault == 0 {
+		decl.Name = p.parseIdentifier()
+	}
+	decl.TypeParameters, decl.Parameters, decl.Type = p.parseSignatureParts()
+	switch p.tok.Kind {
+	case token.Semicolon:
+		p.advance()
+	case token.LBrace:
+		// Function bodies contain statements that this parser does not
+		// support.
+		p.errorExpected(token.Semicolon)
+	default:
+	}
+	decl.Range = p.rangeFrom(start)
+	return decl
+}
+
+func (p *parser) parseClassDeclaration(start token.Pos, modifiers ast.ModifierFlags) *ast.ClassDeclaration {
+	p.eat(token.Class)
+	decl := &ast.ClassDeclaration{Modifiers: modifiers, LeadingComment: p.consumeComment()}
+	if !p.isClassNameOmitted(modifiers) {
+		decl.Name = p.parseIdentifier()
+	}
+	if p.tok.Kind == token.LAngle {
+		decl.TypeParameters = p.parseTypeParameters()
+	}
+	for p.tok.Kind == token.Extends || p.tok.Kind == token.Implements {
+		decl.HeritageClauses = append(decl.HeritageClauses, p.parseHeritageClauses()...)
+	}
 	p.eat(token.LBrace)
-	decl.Body = &ast.ModuleBlock{}
+	for p.tok.Kind != token.RBrace {
+		if p.tok.Kind == token.Semicolon {
+			p.advance()
+			continue
+		}
+		decl.Members = append(decl.Members, p.parseClassElement())
+	}
+	p.eat(token.RBrace)
+	decl.Range = p.rangeFrom(start)
+	return decl
+}
+
+// isClassNameOmitted reports whether the current token follows the name of a
+// class, which may only be omitted in a default export.
+func (p *parser) isClassNameOmitted(modifiers ast.ModifierFlags) bool {
+	if modifiers&ast.ModifierDefault == 0 {
+		return false
+	}
+	return !p.isIdentifier() || p.tok.Kind == token.Implements
+}
+
+func (p *parser) parseClassElement() ast.ClassElement {
+	start := p.tok.Pos
+	leadingComment := p.consumeComment()
+	modifiers := p.parseModifiers()
+
+	switch {
+	case p.tok.Kind == token.LBrack:
+		return p.parseIndexSignature(start, leadingComment, modifiers)
+	case p.tok.Kind == token.Constructor && p.lookahead(p.isFollowedByParameters):
+		return p.parseConstructor(start, leadingComment, modifiers)
+	case p.tok.Kind == token.Get && p.lookahead(p.isFollowedByName):
+		return p.parseGetAccessor(start, leadingComment, modifiers)
+	case p.tok.Kind == token.Set && p.lookahead(p.isFollowedByName):
+		return p.parseSetAccessor(start, leadingComment, modifiers)
+	default:
+		return p.parsePropertyOrMethodDeclaration(start, leadingComment, modifiers)
+	}
+}
+
+// parseModifiers parses the modifier keywords preceding a class member or
+// parameter. A modifier keyword that is not followed by a name is instead
+// treated as the name itself.
+func (p *parser) parseModifiers() ast.ModifierFlags {
+	var modifiers ast.ModifierFlags
 	for {
-		if p.tok.Kind == token.RBrace {
-			p.eat(token.RBrace)
-			return decl
+		modifier, ok := modifierFlag(p.tok.Kind)
+		if !ok || !p.lookahead(p.isFollowedByName) {
+			break
+		}
+		modifiers |= modifier
+		p.advance()
+	}
+	return modifiers
+}
+
+func modifierFlag(kind token.Kind) (ast.ModifierFlags, bool) {
+	switch kind {
+	case token.Declare:
+		return ast.ModifierDeclare, true
+	case token.Public:
+		return ast.ModifierPublic, true
+	case token.Private:
+		return ast.ModifierPrivate, true
+	case token.Protected:
+		return ast.ModifierProtected, true
+	case token.Abstract:
+		return ast.ModifierAbstract, true
+	case token.Static:
+		return ast.ModifierStatic, true
+	case token.Override:
+		return ast.ModifierOverride, true
+	case token.Readonly:
+		return ast.ModifierReadonly, true
+	default:
+		return 0, false
+	}
+}
+
+// isFollowedByName reports whether the token after the current one can begin
+// the name of a class member.
+func (p *parser) isFollowedByName() bool {
+	p.advance()
+	return p.isIdentifierName() || p.tok.Kind == token.LBrack
+}
+
+// isFollowedByParameters reports whether the token after the current one
+// begins a parameter list.
+func (p *parser) isFollowedByParameters() bool {
+	p.advance()
+	return p.tok.Kind == token.LParen
+}
+
+func (p *parser) parseConstructor(start token.Pos, leadingComment string, modifiers ast.ModifierFlags) *ast.Constructor {
+	p.eat(token.Constructor)
+	member := &ast.Constructor{Modifiers: modifiers, LeadingComment: leadingComment}
+	member.Parameters = p.parseParameters()
+	p.parseClassElementEnd()
+	member.Range = p.rangeFrom(start)
+	member.TrailingComment = p.consumeLineComment()
+	return member
+}
+
+func (p *parser) parseGetAccessor(start token.Pos, leadingComment string, modifiers ast.ModifierFlags) *ast.GetAccessor {
+	p.eat(token.Get)
+	member := &ast.GetAccessor{Modifiers: modifiers, LeadingComment: leadingComment}
+	member.Name = p.parseIdentifierName()
+	member.Parameters = p.parseParameters()
+	if p.tok.Kind == token.Colon {
+		p.advance()
+		member.Type = p.parseType()
+	}
+	p.parseClassElementEnd()
+	member.Range = p.rangeFrom(start)
+	member.TrailingComment = p.consumeLineComment()
+	return member
+}
+
+func (p *parser) parseSetAccessor(start token.Pos, leadingComment string, modifiers ast.ModifierFlags) *ast.SetAccessor {
+	p.eat(token.Set)
+	member := &ast.SetAccessor{Modifiers: modifiers, LeadingComment: leadingComment}
+	member.Name = p.parseIdentifierName()
+	member.Parameters = p.parseParameters()
+	p.parseClassElementEnd()
+	member.Range = p.rangeFrom(start)
+	member.TrailingComment = p.consumeLineComment()
+	return member
+}
+
+func (p *parser) parsePropertyOrMethodDeclaration(start token.Pos, leadingComment string, modifiers ast.ModifierFlags) ast.ClassElement {
+	name := p.parseIdentifierName()
+	questionToken := false
+	if p.tok.Kind == token.Question {
+		questionToken = true
+		p.advance()
+	}
+
+	if p.tok.Kind == token.LParen || p.tok.Kind == token.LAngle {
+		member := &ast.MethodDeclaration{
+			Modifiers:      modifiers,
+			Name:           name,
+			QuestionToken:  questionToken,
+			LeadingComment: leadingComment,
 		}
-		decl.Body.Statements = append(decl.Body.Statements, p.parseStatement())
+		member.TypeParameters, member.Parameters, member.Type = p.parseSignatureParts()
+		p.parseClassElementEnd()
+		member.Range = p.rangeFrom(start)
+		member.TrailingComment = p.consumeLineComment()
+		return member
 	}
+
+	member := &ast.PropertyDeclaration{
+		Modifiers:      modifiers,
+		Name:           name,
+		QuestionToken:  questionToken,
+		LeadingComment: leadingComment,
+	}
+	if p.tok.Kind == token.Colon {
+		p.advance()
+		member.Type = p.parseType()
+	}
+	if p.tok.Kind == token.Assign {
+		p.advance()
+		member.Initializer = p.parseInitializer()
+	}
+	p.parseClassElementEnd()
+	member.Range = p.rangeFrom(start)
+	member.TrailingComment = p.consumeLineComment()
+	return member
 }
 
-func (p *parser) parseTypeAliasDeclaration() *ast.TypeAliasDeclaration {
-	p.eat(token.Ident)
-	decl := &ast.TypeAliasDeclaration{LeadingComment: p.consumeComment()}
+// parseClassElementEnd parses the optional semicolon at the end of a class
+// member. Method bodies are not supported.
+func (p *parser) parseClassElementEnd() {
+	switch p.tok.Kind {
+	case token.Semicolon:
+		p.advance()
+	case token.LBrace:
+		p.errorExpected(token.Semicolon)
+	default:
+	}
+}
+
+func (p *parser) parseModuleDeclaration(start token.Pos, modifiers ast.ModifierFlags) *ast.ModuleDeclaration {
+	p.eat(token.Namespace)
+	decl := &ast.ModuleDeclaration{Modifiers: modifiers, LeadingComment: p.consumeComment()}
 	decl.Name = p.parseIdentifier()
+	decl.Body = p.parseModuleBlock()
+	decl.Range = p.rangeFrom(start)
+	return decl
+}
+
+func (p *parser) parseModuleBlock() *ast.ModuleBlock {
+	start := p.tok.Pos
+	p.eat(token.LBrace)
+	block := &ast.ModuleBlock{}
+	for p.tok.Kind != token.RBrace && p.tok.Kind != token.EOF {
+		block.Statements = append(block.Statements, p.parseStatementOrRecover())
+	}
+	p.eat(token.RBrace)
+	block.Range = p.rangeFrom(start)
+	return block
+}
+
+func (p *parser) parseTypeAliasDeclaration(start token.Pos, modifiers ast.ModifierFlags) *ast.TypeAliasDeclaration {
+	p.eat(token.Type)
+	decl := &ast.TypeAliasDeclaration{Modifiers: modifiers, LeadingComment: p.consumeComment()}
+	decl.Name = p.parseIdentifier()
+	if p.tok.Kind == token.LAngle {
+		decl.TypeParameters = p.parseTypeParameters()
+	}
 	p.eat(token.Assign)
 	decl.Type = p.parseType()
+	if p.tok.Kind == token.Semicolon {
+		p.advance()
+	}
+	decl.Range = p.rangeFrom(start)
 	return decl
 }
 
-func (p *parser) parseEnumDeclaration() *ast.EnumDeclaration {
-	p.eat(token.Ident)
-	decl := &ast.EnumDeclaration{LeadingComment: p.consumeComment()}
+func (p *parser) parseEnumDeclaration(start token.Pos, modifiers ast.ModifierFlags) *ast.EnumDeclaration {
+	p.eat(token.Enum)
+	decl := &ast.EnumDeclaration{Modifiers: modifiers, LeadingComment: p.consumeComment()}
 	decl.Name = p.parseIdentifier()
 	p.eat(token.LBrace)
 	for {
@@ -127,21 +758,22 @@ func (p *parser) parseEnumDeclaration() *ast.EnumDeclaration {
 			p.advance()
 		case token.RBrace:
 		default:
-			panic(fmt.Sprintf("unexpected token %s", p.tok))
+			p.errorExpected(token.Comma, token.RBrace)
 		}
 	}
 	p.eat(token.RBrace)
+	decl.Range = p.rangeFrom(start)
 	return decl
 }
 
-func (p *parser) parseInterfaceDeclaration() *ast.InterfaceDeclaration {
-	p.eat(token.Ident)
-	decl := &ast.InterfaceDeclaration{LeadingComment: p.consumeComment()}
+func (p *parser) parseInterfaceDeclaration(start token.Pos, modifiers ast.ModifierFlags) *ast.InterfaceDeclaration {
+	p.eat(token.Interface)
+	decl := &ast.InterfaceDeclaration{Modifiers: modifiers, LeadingComment: p.consumeComment()}
 	decl.Name = p.parseIdentifier()
 	if p.tok.Kind == token.LAngle {
 		decl.TypeParameters = p.parseTypeParameters()
 	}
-	if p.tok.Kind == token.Ident && p.tok.Text == "extends" {
+	if p.tok.Kind == token.Extends {
 		decl.HeritageClauses = p.parseHeritageClauses()
 	}
 	p.eat(token.LBrace)
@@ -152,25 +784,81 @@ func (p *parser) parseInterfaceDeclaration() *ast.InterfaceDeclaration {
 		decl.Members = append(decl.Members, p.parseSignature())
 	}
 	p.eat(token.RBrace)
+	decl.Range = p.rangeFrom(start)
 	return decl
 }
 
 func (p *parser) parseSignature() ast.Signature {
-	switch p.tok.Kind {
-	case token.Ident:
-		return p.parsePropertySignature()
-	case token.LBrack:
-		return p.parseIndexSignature()
+	switch {
+	case p.tok.Kind == token.New && p.lookahead(p.isStartOfConstructSignature):
+		return p.parseConstructSignature()
+	case p.isIdentifierName():
+		return p.parsePropertyOrMethodSignature()
+	case p.tok.Kind == token.LBrack:
+		return p.parseIndexSignature(p.tok.Pos, p.consumeComment(), 0)
+	case p.tok.Kind == token.LParen, p.tok.Kind == token.LAngle:
+		return p.parseCallSignature()
 	default:
-		panic(fmt.Sprintf("unexpected token %s", p.tok))
+		p.errorExpected(token.Ident, token.LBrack, token.LParen, token.LAngle)
+		return nil
 	}
 }
 
+// isStartOfConstructSignature reports whether the parser is at a new keyword
+// that begins a construct signature, rather than a property or method named
+// "new".
+func (p *parser) isStartOfConstructSignature() bool {
+	p.advance()
+	return p.tok.Kind == token.LParen || p.tok.Kind == token.LAngle
+}
+
+// parseSignatureParts parses the optional type parameters, the parameters,
+// and the optional return type of a function or method.
+func (p *parser) parseSignatureParts() ([]*ast.TypeParameter, []*ast.Parameter, ast.Type) {
+	var typeParameters []*ast.TypeParameter
+	if p.tok.Kind == token.LAngle {
+		typeParameters = p.parseTypeParameters()
+	}
+	parameters := p.parseParameters()
+	var typ ast.Type
+	if p.tok.Kind == token.Colon {
+		p.advance()
+		typ = p.parseType()
+	}
+	return typeParameters, parameters, typ
+}
+
+func (p *parser) parseCallSignature() *ast.CallSignature {
+	start := p.tok.Pos
+	signature := &ast.CallSignature{LeadingComment: p.consumeComment()}
+	signature.TypeParameters, signature.Parameters, signature.Type = p.parseSignatureParts()
+	if p.tok.Kind == token.Semicolon {
+		p.advance()
+	}
+	signature.Range = p.rangeFrom(start)
+	signature.TrailingComment = p.consumeLineComment()
+	return signature
+}
+
+func (p *parser) parseConstructSignature() *ast.ConstructSignature {
+	start := p.tok.Pos
+	signature := &ast.ConstructSignature{LeadingComment: p.consumeComment()}
+	p.eat(token.New)
+	signature.TypeParameters, signature.Parameters, signature.Type = p.parseSignatureParts()
+	if p.tok.Kind == token.Semicolon {
+		p.advance()
+	}
+	signature.Range = p.rangeFrom(start)
+	signature.TrailingComment = p.consumeLineComment()
+	return signature
+}
+
 func (p *parser) parseHeritageClauses() []*ast.HeritageClause {
-	p.eat(token.Ident)
+	kind := p.tok.Kind // token.Extends or token.Implements
+	p.advance()
 	var heritageClauses []*ast.HeritageClause
 	for {
-		heritageClauses = append(heritageClauses, p.parseHeritageClause())
+		heritageClauses = append(heritageClauses, p.parseHeritageClause(kind))
 		if p.tok.Kind != token.Comma {
 			break
 		}
@@ -179,14 +867,23 @@ func (p *parser) parseHeritageClauses() []*ast.HeritageClause {
 	return heritageClauses
 }
 
-func (p *parser) parseHeritageClause() *ast.HeritageClause {
+func (p *parser) parseHeritageClause(kind token.Kind) *ast.HeritageClause {
+	start := p.tok.Pos
 	return &ast.HeritageClause{
+		Token: kind,
 		Types: []*ast.ExpressionWithTypeArguments{p.parseExpressionWithTypeArguments()},
+		Range: p.rangeFrom(start),
 	}
 }
 
 func (p *parser) parseExpressionWithTypeArguments() *ast.ExpressionWithTypeArguments {
-	return &ast.ExpressionWithTypeArguments{Expression: p.parseIdentifier()}
+	start := p.tok.Pos
+	expr := &ast.ExpressionWithTypeArguments{Expression: p.parseIdentifier()}
+	if p.isStartOfTypeArguments() {
+		expr.TypeArguments = p.parseTypeArguments()
+	}
+	expr.Range = p.rangeFrom(start)
+	return expr
 }
 
 func (p *parser) parseTypeParameters() []*ast.TypeParameter {
@@ -199,68 +896,197 @@ func (p *parser) parseTypeParameters() []*ast.TypeParameter {
 		}
 		p.advance()
 	}
-	p.eat(token.RAngle)
+	p.eatRAngle()
 	return typeParameters
 }
 
 func (p *parser) parseTypeParameter() *ast.TypeParameter {
-	return &ast.TypeParameter{Name: p.parseIdentifier()}
+	start := p.tok.Pos
+	param := &ast.TypeParameter{}
+	for {
+		modifier, ok := typeParameterModifierFlag(p.tok.Kind)
+		if !ok || !p.lookahead(p.isFollowedByIdent) {
+			break
+		}
+		param.Modifiers |= modifier
+		p.advance()
+	}
+	param.Name = p.parseIdentifier()
+	if p.tok.Kind == token.Extends {
+		p.advance()
+		param.Constraint = p.parseType()
+	}
+	if p.tok.Kind == token.Assign {
+		p.advance()
+		param.Default = p.parseType()
+	}
+	param.Range = p.rangeFrom(start)
+	return param
+}
+
+func typeParameterModifierFlag(kind token.Kind) (ast.ModifierFlags, bool) {
+	switch kind {
+	case token.Const:
+		return ast.ModifierConst, true
+	case token.In:
+		return ast.ModifierIn, true
+	case token.Out:
+		return ast.ModifierOut, true
+	default:
+		return 0, false
+	}
+}
+
+// isFollowedByIdent reports whether the token after the current one is an
+// identifier.
+func (p *parser) isFollowedByIdent() bool {
+	p.advance()
+	return p.isIdentifier()
 }
 
-func (p *parser) parsePropertySignature() *ast.PropertySignature {
-	signature := &ast.PropertySignature{LeadingComment: p.consumeComment()}
-	if p.tok.Kind == token.Ident && p.tok.Text == "readonly" {
+func (p *parser) parsePropertyOrMethodSignature() ast.Signature {
+	start := p.tok.Pos
+	leadingComment := p.consumeComment()
+	var modifiers ast.ModifierFlags
+	if p.tok.Kind == token.Readonly && p.lookahead(p.isFollowedByName) {
 		p.advance()
+		modifiers = ast.ModifierReadonly
+		if p.tok.Kind == token.LBrack {
+			return p.parseIndexSignature(start, leadingComment, modifiers)
+		}
+	}
+	name := p.parseIdentifierName()
+	questionToken := false
+	if p.tok.Kind == token.Question {
+		questionToken = true
+		p.advance()
+	}
+	if p.tok.Kind == token.LParen || p.tok.Kind == token.LAngle {
+		return p.parseMethodSignature(start, leadingComment, name, questionToken)
+	}
+	return p.parsePropertySignature(start, leadingComment, modifiers, name, questionToken)
+}
+
+func (p *parser) parseMethodSignature(start token.Pos, leadingComment string, name *ast.Identifier, questionToken bool) *ast.MethodSignature {
+	signature := &ast.MethodSignature{
+		Name:           name,
+		QuestionToken:  questionToken,
+		LeadingComment: leadingComment,
 	}
-	signature.Name = p.parseIdentifier()
-	if p.tok.Kind == token.Question {
-		signature.QuestionToken = true
+	signature.TypeParameters, signature.Parameters, signature.Type = p.parseSignatureParts()
+	if p.tok.Kind == token.Semicolon {
 		p.advance()
 	}
+	signature.Range = p.rangeFrom(start)
+	signature.TrailingComment = p.consumeLineComment()
+	return signature
+}
+
+func (p *parser) parsePropertySignature(start token.Pos, leadingComment string, modifiers ast.ModifierFlags, name *ast.Identifier, questionToken bool) *ast.PropertySignature {
+	signature := &ast.PropertySignature{
+		Modifiers:      modifiers,
+		Name:           name,
+		QuestionToken:  questionToken,
+		LeadingComment: leadingComment,
+	}
 	p.eat(token.Colon)
 	signature.Type = p.parseType()
 	if p.tok.Kind == token.Semicolon {
 		p.advance()
 	}
+	signature.Range = p.rangeFrom(start)
 	signature.TrailingComment = p.consumeLineComment()
 	return signature
 }
 
 func (p *parser) parseEnumMember() *ast.EnumMember {
+	start := p.tok.Pos
 	member := &ast.EnumMember{
-		Name:           p.parseIdentifier(),
+		Name:           p.parseIdentifierName(),
 		LeadingComment: p.consumeComment(),
 	}
-	if p.tok.Kind != token.Assign {
-		return member
+	if p.tok.Kind == token.Assign {
+		p.advance()
+		member.Initializer = p.parseInitializer()
 	}
-	p.advance()
-	member.Initializer = p.parseInitializer()
+	member.Range = p.rangeFrom(start)
 	return member
 }
 
 func (p *parser) parseInitializer() ast.Expr {
 	switch p.tok.Kind {
 	case token.Number:
-		return &ast.NumericLiteral{Text: p.eat(token.Number).Text}
+		tok := p.eat(token.Number)
+		return &ast.NumericLiteral{Text: tok.Text, Range: tokenRange(tok)}
+	case token.BigInt:
+		tok := p.eat(token.BigInt)
+		return &ast.BigIntLiteral{Text: tok.Text, Range: tokenRange(tok)}
 	case token.String:
-		return &ast.StringLiteral{Text: p.eat(token.String).Text}
+		return p.parseStringLiteral()
+	case token.Regex:
+		tok := p.eat(token.Regex)
+		end := strings.LastIndexByte(tok.Text, '/')
+		return &ast.RegularExpressionLiteral{Pattern: tok.Text[1:end], Flags: tok.Text[end+1:], Range: tokenRange(tok)}
 	case token.Minus:
+		start := p.tok.Pos
 		p.advance()
-		return &ast.PrefixUnaryExpression{
+		expr := &ast.PrefixUnaryExpression{
 			Operator: token.Minus,
 			Operand:  p.parseInitializer(),
 		}
-	case token.Ident:
-		return &ast.TypeReference{TypeName: p.parseIdentifier()}
+		expr.Range = p.rangeFrom(start)
+		return expr
+	case token.True, token.False, token.Null, token.This:
+		start := p.tok.Pos
+		return &ast.TypeReference{TypeName: p.parseIdentifierName(), Range: p.rangeFrom(start)}
 	case token.LBrack:
 		return p.parseArrayLiteralExpression()
+	case token.NoSubstitutionTemplate:
+		tok := p.eat(token.NoSubstitutionTemplate)
+		return &ast.NoSubstitutionTemplateLiteral{Text: tok.Text, Range: tokenRange(tok)}
+	case token.TemplateHead:
+		return p.parseTemplateExpression()
 	default:
-		panic(fmt.Sprintf("unexpected token %s", p.tok))
+		if p.isIdentifier() {
+			start := p.tok.Pos
+			return &ast.TypeReference{TypeName: p.parseIdentifier(), Range: p.rangeFrom(start)}
+		}
+		p.errorExpected(token.Number, token.BigInt, token.String, token.Regex, token.Minus, token.Ident, token.LBrack, token.NoSubstitutionTemplate, token.TemplateHead)
+		return nil
+	}
+}
+
+func (p *parser) parseTemplateExpression() *ast.TemplateExpression {
+	start := p.tok.Pos
+	expr := &ast.TemplateExpression{Head: p.eat(token.TemplateHead).Text}
+	for {
+		spanStart := p.tok.Pos
+		span := &ast.TemplateSpan{Expression: p.parseInitializer()}
+		tok := p.parseTemplateContinuation()
+		span.Literal = tok.Text
+		span.Range = p.rangeFrom(spanStart)
+		expr.TemplateSpans = append(expr.TemplateSpans, span)
+		if tok.Kind == token.TemplateTail {
+			break
+		}
+	}
+	expr.Range = p.rangeFrom(start)
+	return expr
+}
+
+// parseTemplateContinuation parses the template text following a
+// substitution.
+func (p *parser) parseTemplateContinuation() token.Token {
+	tok := p.tok
+	if tok.Kind != token.TemplateMiddle && tok.Kind != token.TemplateTail {
+		p.errorExpected(token.TemplateMiddle, token.TemplateTail)
 	}
+	p.advance()
+	return tok
 }
 
 func (p *parser) parseArrayLiteralExpression() *ast.ArrayLiteralExpression {
+	start := p.tok.Pos
 	p.eat(token.LBrack)
 	expr := &ast.ArrayLiteralExpression{}
 	for p.tok.Kind != token.RBrack {
@@ -270,125 +1096,648 @@ func (p *parser) parseArrayLiteralExpression() *ast.ArrayLiteralExpression {
 		}
 	}
 	p.eat(token.RBrack)
+	expr.Range = p.rangeFrom(start)
 	return expr
 }
 
+func (p *parser) parseStringLiteral() *ast.StringLiteral {
+	tok := p.eat(token.String)
+	// The scanner has already rejected strings with invalid escape sequences.
+	value, _ := scanner.DecodeString(tok.Text)
+	return &ast.StringLiteral{
+		Text:  tok.Text,
+		Value: value,
+		Quote: p.lex.Source[tok.Pos.Offset],
+		Range: tokenRange(tok),
+	}
+}
+
+// parseIdentifier parses an identifier, which may be a contextual keyword.
 func (p *parser) parseIdentifier() *ast.Identifier {
-	return &ast.Identifier{Text: p.eat(token.Ident).Text}
+	if !p.isIdentifier() {
+		p.errorExpected(token.Ident)
+	}
+	return p.parseIdentifierName()
+}
+
+// parseIdentifierName parses an identifier or any keyword, as allowed in
+// positions such as property names.
+func (p *parser) parseIdentifierName() *ast.Identifier {
+	if !p.isIdentifierName() {
+		p.errorExpected(token.Ident)
+	}
+	tok := p.tok
+	p.advance()
+	return &ast.Identifier{Text: tok.Text, Range: tokenRange(tok)}
+}
+
+// isIdentifier reports whether the current token is an identifier or a
+// contextual keyword used as one.
+func (p *parser) isIdentifier() bool {
+	return p.tok.Kind == token.Ident || p.tok.Kind.IsContextualKeyword()
+}
+
+// isIdentifierName reports whether the current token is an identifier or any
+// keyword.
+func (p *parser) isIdentifierName() bool {
+	return p.tok.Kind == token.Ident || p.tok.Kind.IsKeyword()
 }
 
 func (p *parser) parseType() ast.Type {
-	return p.parseTypeCheckUnion()
+	start := p.tok.Pos
+	typ := p.parseTypeCheckUnion()
+	if p.disallowConditionalTypes || p.hasPrecedingLineBreak() || p.tok.Kind != token.Extends {
+		return typ
+	}
+
+	p.advance()
+	cond := &ast.ConditionalType{CheckType: typ}
+	cond.ExtendsType = p.parseTypeDisallowingConditionalTypes()
+	p.eat(token.Question)
+	cond.TrueType = p.parseType()
+	p.eat(token.Colon)
+	cond.FalseType = p.parseType()
+	cond.Range = p.rangeFrom(start)
+	return cond
+}
+
+// parseTypeDisallowingConditionalTypes parses a type in which an
+// unparenthesized extends keyword does not begin a conditional type.
+func (p *parser) parseTypeDisallowingConditionalTypes() ast.Type {
+	saved := p.disallowConditionalTypes
+	p.disallowConditionalTypes = true
+	defer func() {
+		p.disallowConditionalTypes = saved
+	}()
+	return p.parseType()
 }
 
 func (p *parser) parseTypeCheckUnion() ast.Type {
-	typ := p.parseTypeCheckArray()
-	if p.tok.Kind != token.Or {
-		return typ
+	start := p.tok.Pos
+	types, ok := p.parseTypeList(token.Or, p.parseTypeCheckIntersection)
+	if !ok {
+		return types[0]
 	}
+	return &ast.UnionType{Types: types, Range: p.rangeFrom(start)}
+}
 
-	types := []ast.Type{typ}
-	for {
-		p.eat(token.Or)
-		types = append(types, p.parseTypeCheckArray())
-		if p.tok.Kind != token.Or {
-			return &ast.UnionType{Types: types}
+func (p *parser) parseTypeCheckIntersection() ast.Type {
+	start := p.tok.Pos
+	types, ok := p.parseTypeList(token.And, p.parseTypeCheckOperator)
+	if !ok {
+		return types[0]
+	}
+	return &ast.IntersectionType{Types: types, Range: p.rangeFrom(start)}
+}
+
+// parseTypeList parses a list of types separated by the operator, which may
+// also precede the first type. It reports whether the types form a union or
+// intersection, which is the case if there is more than one type or a leading
+// operator.
+func (p *parser) parseTypeList(operator token.Kind, parseType func() ast.Type) (types []ast.Type, ok bool) {
+	if p.tok.Kind == operator {
+		p.advance()
+		ok = true
+	}
+	types = append(types, parseType())
+	for p.tok.Kind == operator {
+		p.advance()
+		types = append(types, parseType())
+		ok = true
+	}
+	return types, ok
+}
+
+func (p *parser) parseTypeCheckOperator() ast.Type {
+	switch p.tok.Kind {
+	case token.Infer:
+		if p.lookahead(p.isFollowedByIdent) {
+			return p.parseInferType()
 		}
+	case token.Keyof, token.Unique, token.Readonly:
+		return p.parseTypeOperator(p.tok.Kind)
+	default:
+	}
+	return p.parseTypeCheckArray()
+}
+
+func (p *parser) parseTypeOperator(operator token.Kind) *ast.TypeOperator {
+	start := p.tok.Pos
+	p.advance()
+	typ := &ast.TypeOperator{Operator: operator, Type: p.parseTypeCheckOperator()}
+	typ.Range = p.rangeFrom(start)
+	return typ
+}
+
+func (p *parser) parseInferType() *ast.InferType {
+	start := p.tok.Pos
+	p.advance()
+	paramStart := p.tok.Pos
+	param := &ast.TypeParameter{Name: p.parseIdentifier()}
+	if p.tok.Kind == token.Extends && p.lookahead(p.isInferTypeConstraint) {
+		p.advance()
+		param.Constraint = p.parseTypeDisallowingConditionalTypes()
 	}
+	param.Range = p.rangeFrom(paramStart)
+	return &ast.InferType{TypeParameter: param, Range: p.rangeFrom(start)}
+}
+
+// isInferTypeConstraint reports whether the extends keyword following an
+// inferred type variable begins a constraint on the variable, rather than a
+// conditional type in which the inferred type is the check type.
+func (p *parser) isInferTypeConstraint() bool {
+	p.advance()
+	p.parseTypeDisallowingConditionalTypes()
+	return p.disallowConditionalTypes || p.tok.Kind != token.Question
 }
 
 func (p *parser) parseTypeCheckArray() ast.Type {
+	start := p.tok.Pos
 	typ := p.parseTypeInner()
-	if p.tok.Kind != token.LBrack {
-		return typ
+	// A bracket on the next line begins a new member, such as an index
+	// signature, rather than an array or indexed access type.
+	for p.tok.Kind == token.LBrack && !p.hasPrecedingLineBreak() {
+		p.advance()
+		if p.tok.Kind == token.RBrack {
+			p.advance()
+			typ = &ast.ArrayType{ElementType: typ, Range: p.rangeFrom(start)}
+			continue
+		}
+		indexType := p.parseType()
+		p.eat(token.RBrack)
+		typ = &ast.IndexedAccessType{ObjectType: typ, IndexType: indexType, Range: p.rangeFrom(start)}
 	}
-
-	p.eat(token.LBrack)
-	p.eat(token.RBrack)
-	return &ast.ArrayType{ElementType: typ}
+	return typ
 }
 
 func (p *parser) parseTypeInner() ast.Type {
+	// Conditional types are allowed again inside brackets.
+	saved := p.disallowConditionalTypes
+	p.disallowConditionalTypes = false
+	defer func() {
+		p.disallowConditionalTypes = saved
+	}()
+
 	switch p.tok.Kind {
-	case token.Ident:
+	case token.Ident, token.Void, token.Null, token.This, token.True, token.False:
 		return p.parseTypeReference()
+	case token.Typeof:
+		return p.parseTypeQuery()
 	case token.LBrace:
+		if p.lookahead(p.isStartOfMappedType) {
+			return p.parseMappedType()
+		}
 		return p.parseTypeLiteral()
 	case token.LParen:
+		if p.lookahead(p.isStartOfFunctionType) {
+			return p.parseFunctionType()
+		}
 		return p.parseParenthesizedType()
+	case token.LAngle:
+		return p.parseFunctionType()
 	case token.LBrack:
 		return p.parseTupleType()
 	case token.String:
-		return &ast.LiteralType{Literal: &ast.StringLiteral{Text: p.eat(token.String).Text}}
+		lit := p.parseStringLiteral()
+		return &ast.LiteralType{Literal: lit, Range: lit.Range}
 	case token.Number:
-		return &ast.LiteralType{Literal: &ast.NumericLiteral{Text: p.eat(token.Number).Text}}
+		tok := p.eat(token.Number)
+		return &ast.LiteralType{
+			Literal: &ast.NumericLiteral{Text: tok.Text, Range: tokenRange(tok)},
+			Range:   tokenRange(tok),
+		}
+	case token.BigInt:
+		tok := p.eat(token.BigInt)
+		return &ast.LiteralType{
+			Literal: &ast.BigIntLiteral{Text: tok.Text, Range: tokenRange(tok)},
+			Range:   tokenRange(tok),
+		}
+	case token.Minus:
+		start := p.tok.Pos
+		p.advance()
+		expr := &ast.PrefixUnaryExpression{Operator: token.Minus}
+		switch p.tok.Kind {
+		case token.Number:
+			tok := p.eat(token.Number)
+			expr.Operand = &ast.NumericLiteral{Text: tok.Text, Range: tokenRange(tok)}
+		case token.BigInt:
+			tok := p.eat(token.BigInt)
+			expr.Operand = &ast.BigIntLiteral{Text: tok.Text, Range: tokenRange(tok)}
+		default:
+			p.errorExpected(token.Number, token.BigInt)
+		}
+		expr.Range = p.rangeFrom(start)
+		return &ast.LiteralType{Literal: expr, Range: p.rangeFrom(start)}
+	case token.NoSubstitutionTemplate:
+		tok := p.eat(token.NoSubstitutionTemplate)
+		return &ast.LiteralType{
+			Literal: &ast.NoSubstitutionTemplateLiteral{Text: tok.Text, Range: tokenRange(tok)},
+			Range:   tokenRange(tok),
+		}
+	case token.TemplateHead:
+		return p.parseTemplateLiteralType()
+	default:
+		if p.isIdentifier() {
+			return p.parseTypeReference()
+		}
+		expected := []token.Kind{token.Ident, token.LBrace, token.LParen, token.LAngle, token.LBrack, token.String, token.Number, token.BigInt, token.Minus, token.NoSubstitutionTemplate, token.TemplateHead}
+		if p.mode&RecoverErrors == 0 {
+			p.errorExpected(expected...)
+		}
+		if err := p.newError(expected); err.Token.Kind != token.Illegal {
+			p.errors = append(p.errors, err)
+		}
+		return p.parseBadType()
+	}
+}
+
+func (p *parser) parseTemplateLiteralType() *ast.TemplateLiteralType {
+	start := p.tok.Pos
+	typ := &ast.TemplateLiteralType{Head: p.eat(token.TemplateHead).Text}
+	for {
+		spanStart := p.tok.Pos
+		span := &ast.TemplateLiteralTypeSpan{Type: p.parseType()}
+		tok := p.parseTemplateContinuation()
+		span.Literal = tok.Text
+		span.Range = p.rangeFrom(spanStart)
+		typ.TemplateSpans = append(typ.TemplateSpans, span)
+		if tok.Kind == token.TemplateTail {
+			break
+		}
+	}
+	typ.Range = p.rangeFrom(start)
+	return typ
+}
+
+// parseBadType skips from the start of a type to the next token that is not
+// nested inside brackets and may follow a type, or begins a statement, or
+// begins a member on a new line.
+func (p *parser) parseBadType() *ast.BadType {
+	start := p.tok.Pos
+	depth := 0
+
+	for p.tok.Kind != token.EOF {
+		if depth == 0 && (p.isStartOfStatement() || p.hasPrecedingLineBreak() && p.lookahead(p.isStartOfMember)) {
+			return &ast.BadType{Range: p.rangeFrom(start)}
+		}
+		switch p.tok.Kind {
+		case token.LBrace, token.LParen, token.LBrack, token.LAngle:
+			depth++
+		case token.RBrace, token.RParen, token.RBrack, token.RAngle:
+			if depth == 0 {
+				return &ast.BadType{Range: p.rangeFrom(start)}
+			}
+			depth--
+		case token.Semicolon, token.Comma, token.Assign, token.Or, token.And:
+			if depth == 0 {
+				return &ast.BadType{Range: p.rangeFrom(start)}
+			}
+		default:
+		}
+		p.advance()
+	}
+
+	return &ast.BadType{Range: p.rangeFrom(start)}
+}
+
+// isStartOfMember reports whether the current token begins a member of an
+// interface, type literal or class: an index, call or construct signature,
+// or a name followed by the rest of a property or method.
+func (p *parser) isStartOfMember() bool {
+	p.parseModifiers()
+	switch {
+	case p.tok.Kind == token.LBrack, p.tok.Kind == token.LParen, p.tok.Kind == token.LAngle:
+		return true
+	case p.isIdentifierName(), p.tok.Kind == token.String, p.tok.Kind == token.Number:
+		p.advance()
+		switch p.tok.Kind {
+		case token.Colon, token.Question, token.LParen, token.LAngle:
+			return true
+		default:
+			return false
+		}
 	default:
-		panic(fmt.Sprintf("unexpected token %s", p.tok))
+		return false
 	}
 }
 
 func (p *parser) parseTupleType() *ast.TupleType {
+	start := p.tok.Pos
 	p.eat(token.LBrack)
 	els := []ast.Type{}
 	for {
-		els = append(els, p.parseType())
+		els = append(els, p.parseTupleElementType())
 		if p.tok.Kind != token.Comma {
 			p.eat(token.RBrack)
-			return &ast.TupleType{Elements: els}
+			return &ast.TupleType{Elements: els, Range: p.rangeFrom(start)}
 		}
 		p.advance()
 	}
 }
 
+func (p *parser) parseTupleElementType() ast.Type {
+	if p.tok.Kind != token.Ellipsis {
+		return p.parseType()
+	}
+	start := p.tok.Pos
+	p.advance()
+	return &ast.RestType{Type: p.parseType(), Range: p.rangeFrom(start)}
+}
+
 func (p *parser) parseTypeReference() *ast.TypeReference {
-	first := p.parseIdentifier()
-	if p.tok.Kind != token.Dot {
-		return &ast.TypeReference{TypeName: first}
+	start := p.tok.Pos
+	typ := &ast.TypeReference{TypeName: p.parseEntityName()}
+	if p.isStartOfTypeArguments() {
+		typ.TypeArguments = p.parseTypeArguments()
 	}
+	typ.Range = p.rangeFrom(start)
+	return typ
+}
+
+func (p *parser) parseTypeQuery() *ast.TypeQuery {
+	start := p.tok.Pos
 	p.advance()
-	return &ast.TypeReference{TypeName: &ast.QualifiedName{Left: first, Right: p.parseIdentifier()}}
+	typ := &ast.TypeQuery{ExprName: p.parseEntityName()}
+	if p.isStartOfTypeArguments() && !p.hasPrecedingLineBreak() {
+		typ.TypeArguments = p.parseTypeArguments()
+	}
+	typ.Range = p.rangeFrom(start)
+	return typ
+}
+
+// parseEntityName parses an identifier or a dotted sequence of identifiers,
+// such as A.B.C. The leading identifier may be a keyword that names a type,
+// such as void or this.
+func (p *parser) parseEntityName() ast.Expr {
+	start := p.tok.Pos
+	var name ast.Expr
+	switch p.tok.Kind {
+	case token.Void, token.Null, token.This, token.True, token.False:
+		name = p.parseIdentifierName()
+	default:
+		name = p.parseIdentifier()
+	}
+	for p.tok.Kind == token.Dot {
+		p.advance()
+		qualified := &ast.QualifiedName{Left: name, Right: p.parseIdentifierName()}
+		qualified.Range = p.rangeFrom(start)
+		name = qualified
+	}
+	return name
+}
+
+func (p *parser) parseTypeArguments() []ast.Type {
+	p.eatLAngle()
+	var typeArguments []ast.Type
+	for {
+		typeArguments = append(typeArguments, p.parseType())
+		if p.tok.Kind != token.Comma {
+			break
+		}
+		p.advance()
+	}
+	p.eatRAngle()
+	return typeArguments
+}
+
+// isStartOfFunctionType reports whether the parser is at an opening
+// parenthesis that begins the parameter list of a function type, rather than
+// a parenthesized type.
+func (p *parser) isStartOfFunctionType() bool {
+	p.eat(token.LParen)
+	switch {
+	case p.tok.Kind == token.RParen, p.tok.Kind == token.Ellipsis:
+		return true
+	case p.isIdentifier(), p.tok.Kind == token.This:
+		p.advance()
+		switch p.tok.Kind {
+		case token.Colon, token.Comma, token.Question, token.Assign:
+			return true
+		case token.RParen:
+			p.advance()
+			return p.tok.Kind == token.Arrow
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+func (p *parser) parseFunctionType() *ast.FunctionType {
+	start := p.tok.Pos
+	typ := &ast.FunctionType{}
+	if p.tok.Kind == token.LAngle {
+		typ.TypeParameters = p.parseTypeParameters()
+	}
+	typ.Parameters = p.parseParameters()
+	p.eat(token.Arrow)
+	typ.Type = p.parseType()
+	typ.Range = p.rangeFrom(start)
+	return typ
 }
 
 func (p *parser) parseParenthesizedType() *ast.ParenthesizedType {
+	start := p.tok.Pos
 	p.eat(token.LParen)
 	typ := p.parseType()
 	p.eat(token.RParen)
-	return &ast.ParenthesizedType{Type: typ}
+	return &ast.ParenthesizedType{Type: typ, Range: p.rangeFrom(start)}
 }
 
 func (p *parser) parseTypeLiteral() *ast.TypeLiteral {
+	start := p.tok.Pos
 	p.eat(token.LBrace)
 	literal := &ast.TypeLiteral{}
 	for {
 		if p.tok.Kind == token.RBrace {
 			p.eat(token.RBrace)
+			literal.Range = p.rangeFrom(start)
 			return literal
 		}
 		literal.Members = append(literal.Members, p.parseSignature())
 	}
 }
 
-func (p *parser) parseIndexSignature() *ast.IndexSignature {
-	signature := &ast.IndexSignature{LeadingComment: p.consumeComment()}
+// isStartOfMappedType reports whether the parser is at an opening brace that
+// begins a mapped type, rather than a type literal.
+func (p *parser) isStartOfMappedType() bool {
+	p.eat(token.LBrace)
+	switch p.tok.Kind {
+	case token.Plus, token.Minus:
+		p.advance()
+		return p.tok.Kind == token.Readonly
+	case token.Readonly:
+		p.advance()
+	default:
+	}
+	if p.tok.Kind != token.LBrack {
+		return false
+	}
+	p.advance()
+	if !p.isIdentifier() {
+		return false
+	}
+	p.advance()
+	return p.tok.Kind == token.In
+}
+
+func (p *parser) parseMappedType() *ast.MappedType {
+	start := p.tok.Pos
+	p.eat(token.LBrace)
+	typ := &ast.MappedType{}
+	switch p.tok.Kind {
+	case token.Plus, token.Minus:
+		typ.ReadonlyToken = p.tok.Kind
+		p.advance()
+		p.eat(token.Readonly)
+	case token.Readonly:
+		typ.ReadonlyToken = token.Readonly
+		p.advance()
+	default:
+	}
+
+	p.eat(token.LBrack)
+	paramStart := p.tok.Pos
+	param := &ast.TypeParameter{Name: p.parseIdentifier()}
+	p.eat(token.In)
+	param.Constraint = p.parseType()
+	param.Range = p.rangeFrom(paramStart)
+	typ.TypeParameter = param
+	if p.tok.Kind == token.As {
+		p.advance()
+		typ.NameType = p.parseType()
+	}
+	p.eat(token.RBrack)
+
+	switch p.tok.Kind {
+	case token.Plus, token.Minus:
+		typ.QuestionToken = p.tok.Kind
+		p.advance()
+		p.eat(token.Question)
+	case token.Question:
+		typ.QuestionToken = token.Question
+		p.advance()
+	default:
+	}
+	if p.tok.Kind == token.Colon {
+		p.advance()
+		typ.Type = p.parseType()
+	}
+	if p.tok.Kind == token.Semicolon || p.tok.Kind == token.Comma {
+		p.advance()
+	}
+	p.eat(token.RBrace)
+	typ.Range = p.rangeFrom(start)
+	return typ
+}
+
+func (p *parser) parseIndexSignature(start token.Pos, leadingComment string, modifiers ast.ModifierFlags) *ast.IndexSignature {
+	signature := &ast.IndexSignature{Modifiers: modifiers, LeadingComment: leadingComment}
 	p.eat(token.LBrack)
 	for p.tok.Kind != token.RBrack {
 		signature.Parameters = append(signature.Parameters, p.parseParameter())
 	}
-	p.consumeLineComment() // Throw away
+	p.consumeLineComment() // Kept only in the source file's comments
 	p.eat(token.RBrack)
 	p.eat(token.Colon)
 	signature.Type = p.parseType()
 	if p.tok.Kind == token.Semicolon {
 		p.advance()
 	}
+	signature.Range = p.rangeFrom(start)
 	return signature
 }
 
+func (p *parser) parseParameters() []*ast.Parameter {
+	p.eat(token.LParen)
+	var params []*ast.Parameter
+	for p.tok.Kind != token.RParen {
+		params = append(params, p.parseParameter())
+		if p.tok.Kind != token.Comma {
+			break
+		}
+		p.advance()
+	}
+	p.eat(token.RParen)
+	return params
+}
+
 func (p *parser) parseParameter() *ast.Parameter {
-	name := p.parseIdentifier()
-	p.eat(token.Colon)
-	typ := p.parseType()
-	return &ast.Parameter{Name: name, Type: typ}
+	start := p.tok.Pos
+	param := &ast.Parameter{Modifiers: p.parseModifiers()}
+	if p.tok.Kind == token.Ellipsis {
+		param.DotDotDotToken = true
+		p.advance()
+	}
+	if p.tok.Kind == token.This {
+		param.Name = p.parseIdentifierName()
+	} else {
+		param.Name = p.parseIdentifier()
+	}
+	if p.tok.Kind == token.Question {
+		param.QuestionToken = true
+		p.advance()
+	}
+	if p.tok.Kind == token.Colon {
+		p.advance()
+		param.Type = p.parseType()
+	}
+	if p.tok.Kind == token.Assign {
+		p.advance()
+		param.Initializer = p.parseInitializer()
+	}
+	param.Range = p.rangeFrom(start)
+	return param
+}
+
+// rAngleRemainders maps each token kind that begins with > to the kind of
+// the characters following the >.
+var rAngleRemainders = map[token.Kind]token.Kind{
+	token.Shr:        token.RAngle,
+	token.UShr:       token.Shr,
+	token.GreaterEq:  token.Assign,
+	token.ShrAssign:  token.GreaterEq,
+	token.UShrAssign: token.ShrAssign,
+}
+
+// eatRAngle consumes a > token that closes a list of type parameters or
+// arguments. A token that begins with >, such as the >> in A<B<C>>, is split
+// so that the remaining characters are read as the next token.
+func (p *parser) eatRAngle() {
+	if kind, ok := rAngleRemainders[p.tok.Kind]; ok {
+		p.splitToken(token.RAngle, kind)
+	}
+	p.eat(token.RAngle)
+}
+
+// isStartOfTypeArguments reports whether the parser is at a < token, or at
+// a << token that opens a list of type arguments beginning with a generic
+// function type, as in A<<T>() => void>.
+func (p *parser) isStartOfTypeArguments() bool {
+	return p.tok.Kind == token.LAngle || p.tok.Kind == token.Shl
+}
+
+// eatLAngle consumes a < token that opens a list of type arguments, splitting
+// a << token so that the second < is read as the next token.
+func (p *parser) eatLAngle() {
+	if p.tok.Kind == token.Shl {
+		p.splitToken(token.LAngle, token.LAngle)
+	}
+	p.eat(token.LAngle)
+}
+
+// splitToken splits the current token after its first character, which
+// becomes a token of the given kind, and pushes back the remaining
+// characters as a token of kind rest.
+func (p *parser) splitToken(kind, rest token.Kind) {
+	next := p.tok
+	next.Kind = rest
+	next.Pos.Offset++
+	next.Pos.Column++
+	p.lex.Unread(next)
+	p.tok.Kind = kind
+	p.tok.End = next.Pos
 }
 
 func (p *parser) eat(kind token.Kind) token.Token {
@@ -400,24 +1749,130 @@ func (p *parser) eat(kind token.Kind) token.Token {
 
 func (p *parser) expect(kind token.Kind) {
 	if p.tok.Kind != kind {
-		panic(fmt.Sprintf("expected kind %s, got %s", kind, p.tok))
+		p.errorExpected(kind)
+	}
+}
+
+// errorExpected aborts parsing with an error at the current token. The
+// expected kinds are the token kinds that would have been accepted instead.
+func (p *parser) errorExpected(expected ...token.Kind) {
+	panic(bailout{err: p.newError(expected)})
+}
+
+// newError returns an error at the current token. The error for an Illegal
+// token is the one already recorded by scanError.
+func (p *parser) newError(expected []token.Kind) *Error {
+	if p.tok.Kind == token.Illegal {
+		for _, err := range p.errors {
+			if err.Pos == p.tok.Pos {
+				err.Token = p.tok
+				return err
+			}
+		}
+	}
+	return newError(p.tok, expected)
+}
+
+// scanError records a syntax error reported by the scanner, which returns an
+// Illegal token in place of the invalid source.
+func (p *parser) scanError(pos token.Pos, msg string) {
+	for _, err := range p.errors {
+		if err.Pos == pos {
+			return // reported again after recovery rescanned the source
+		}
 	}
+	p.errors = append(p.errors, &Error{
+		Pos:   pos,
+		Token: token.Token{Kind: token.Illegal, Pos: pos},
+		Msg:   msg,
+	})
+}
+
+// errorUnexpected aborts parsing with an error at the current token.
+func (p *parser) errorUnexpected() {
+	p.errorExpected()
 }
 
 func (p *parser) advance() {
+	p.prevEnd = p.tok.End
 	for {
 		p.tok = p.lex.Pop()
 		switch p.tok.Kind {
 		case token.Comment:
+			p.addComment()
 			p.lastComment = p.tok.Text
 		case token.LineComment:
+			p.addComment()
 			p.lastLineComment = p.tok.Text
 		default:
+			p.isCommentGroupOpen = false
 			return
 		}
 	}
 }
 
+// addComment records the current comment token for the source file's list
+// of comment groups.
+func (p *parser) addComment() {
+	// A comment at the end of a line does not share a group with a comment
+	// on the following line.
+	isAdjacent := p.tok.Pos.Line <= p.lastCommentTok.End.Line+1 &&
+		!(p.lastCommentTok.Kind == token.LineComment && p.tok.Kind == token.Comment)
+	if !p.isCommentGroupOpen || !isAdjacent {
+		p.commentGroupStarts = append(p.commentGroupStarts, len(p.comments))
+	}
+
+	p.comments = append(p.comments, &ast.Comment{
+		Text:  string(p.lex.Source[p.tok.Pos.Offset:p.tok.End.Offset]),
+		Range: tokenRange(p.tok),
+	})
+	p.lastCommentTok = p.tok
+	p.isCommentGroupOpen = true
+}
+
+// commentGroups returns the comments read so far, split into groups.
+func (p *parser) commentGroups() []*ast.CommentGroup {
+	var groups []*ast.CommentGroup
+	for i, start := range p.commentGroupStarts {
+		end := len(p.comments)
+		if i+1 < len(p.commentGroupStarts) {
+			end = p.commentGroupStarts[i+1]
+		}
+		groups = append(groups, &ast.CommentGroup{List: p.comments[start:end:end]})
+	}
+	return groups
+}
+
+// lookahead calls f and returns its result, then restores the parser to its
+// state before f was called.
+func (p *parser) lookahead(f func() bool) bool {
+	saved, savedLex := *p, *p.lex
+	defer func() {
+		*p, *p.lex = saved, savedLex
+	}()
+	return f()
+}
+
+// hasPrecedingLineBreak reports whether there is a line break between the
+// current token and the previous one.
+func (p *parser) hasPrecedingLineBreak() bool {
+	return p.tok.Pos.Line > p.prevEnd.Line
+}
+
+// rangeFrom returns the range from start to the end of the last consumed
+// token. If no token has been consumed since start, the range is empty.
+func (p *parser) rangeFrom(start token.Pos) ast.Range {
+	if p.prevEnd.Offset < start.Offset {
+		return ast.Range{StartPos: start, EndPos: start}
+	}
+	return ast.Range{StartPos: start, EndPos: p.prevEnd}
+}
+
+// tokenRange returns the range of a single token.
+func tokenRange(tok token.Token) ast.Range {
+	return ast.Range{StartPos: tok.Pos, EndPos: tok.End}
+}
+
 func (p *parser) consumeComment() string {
 	comment := p.lastComment
 	p.lastComment = ""
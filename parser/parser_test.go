@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -8,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/armsnyder/typescript-ast-go/ast"
+	"github.com/armsnyder/typescript-ast-go/internal/asttest"
 	"github.com/armsnyder/typescript-ast-go/parser"
 	"github.com/armsnyder/typescript-ast-go/token"
 )
@@ -63,7 +65,8 @@ func TestParser_Testdata(t *testing.T) {
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.TypeAliasDeclaration{
-						Name: &ast.Identifier{Text: "LSPArray"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "LSPArray"},
 						Type: &ast.ArrayType{
 							ElementType: &ast.TypeReference{
 								TypeName: &ast.Identifier{Text: "LSPAny"},
@@ -79,7 +82,8 @@ func TestParser_Testdata(t *testing.T) {
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.TypeAliasDeclaration{
-						Name: &ast.Identifier{Text: "integer"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "integer"},
 						Type: &ast.TypeReference{
 							TypeName: &ast.Identifier{Text: "number"},
 						},
@@ -93,32 +97,33 @@ func TestParser_Testdata(t *testing.T) {
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.EnumDeclaration{
-						Name: &ast.Identifier{Text: "SemanticTokenTypes"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "SemanticTokenTypes"},
 						Members: []*ast.EnumMember{
 							{
 								Name:        &ast.Identifier{Text: "namespace"},
-								Initializer: &ast.StringLiteral{Text: "namespace"},
+								Initializer: &ast.StringLiteral{Text: "namespace", Value: "namespace", Quote: '\''},
 							},
 							{
 								Name:           &ast.Identifier{Text: "type"},
-								Initializer:    &ast.StringLiteral{Text: "type"},
+								Initializer:    &ast.StringLiteral{Text: "type", Value: "type", Quote: '\''},
 								LeadingComment: "Represents a generic type. Acts as a fallback for types which\ncan't be mapped to a specific type like class or enum.",
 							},
 							{
 								Name:        &ast.Identifier{Text: "class"},
-								Initializer: &ast.StringLiteral{Text: "class"},
+								Initializer: &ast.StringLiteral{Text: "class", Value: "class", Quote: '\''},
 							},
 							{
 								Name:        &ast.Identifier{Text: "enum"},
-								Initializer: &ast.StringLiteral{Text: "enum"},
+								Initializer: &ast.StringLiteral{Text: "enum", Value: "enum", Quote: '\''},
 							},
 							{
 								Name:        &ast.Identifier{Text: "interface"},
-								Initializer: &ast.StringLiteral{Text: "interface"},
+								Initializer: &ast.StringLiteral{Text: "interface", Value: "interface", Quote: '\''},
 							},
 							{
 								Name:        &ast.Identifier{Text: "string"},
-								Initializer: &ast.StringLiteral{Text: "string"},
+								Initializer: &ast.StringLiteral{Text: "string", Value: "string", Quote: '\''},
 							},
 						},
 					},
@@ -200,6 +205,7 @@ func TestParser_Testdata(t *testing.T) {
 						Name: &ast.Identifier{Text: "ResponseMessage"},
 						HeritageClauses: []*ast.HeritageClause{
 							{
+								Token: token.Extends,
 								Types: []*ast.ExpressionWithTypeArguments{
 									{Expression: &ast.Identifier{Text: "Message"}},
 								},
@@ -250,7 +256,8 @@ func TestParser_Testdata(t *testing.T) {
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.InterfaceDeclaration{
-						Name: &ast.Identifier{Text: "WorkspaceEdit"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "WorkspaceEdit"},
 						Members: []ast.Signature{
 							&ast.PropertySignature{
 								Name:          &ast.Identifier{Text: "changes"},
@@ -363,7 +370,8 @@ Whether clients honor this property depends on the client capability
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.InterfaceDeclaration{
-						Name: &ast.Identifier{Text: "TextDocumentEdit"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "TextDocumentEdit"},
 						Members: []ast.Signature{
 							&ast.PropertySignature{
 								Name: &ast.Identifier{Text: "edits"},
@@ -397,7 +405,8 @@ client capability ` + "`workspace.workspaceEdit.changeAnnotationSupport`"),
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.InterfaceDeclaration{
-						Name: &ast.Identifier{Text: "NotebookDocumentSyncOptions"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "NotebookDocumentSyncOptions"},
 						Members: []ast.Signature{
 							&ast.PropertySignature{
 								Name: &ast.Identifier{Text: "notebookSelector"},
@@ -513,14 +522,17 @@ cell will be synced.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.InterfaceDeclaration{
-						Name: &ast.Identifier{Text: "NotebookDocumentSyncRegistrationOptions"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "NotebookDocumentSyncRegistrationOptions"},
 						HeritageClauses: []*ast.HeritageClause{
 							{
+								Token: token.Extends,
 								Types: []*ast.ExpressionWithTypeArguments{
 									{Expression: &ast.Identifier{Text: "NotebookDocumentSyncOptions"}},
 								},
 							},
 							{
+								Token: token.Extends,
 								Types: []*ast.ExpressionWithTypeArguments{
 									{Expression: &ast.Identifier{Text: "StaticRegistrationOptions"}},
 								},
@@ -536,10 +548,12 @@ cell will be synced.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.ModuleDeclaration{
-						Name: &ast.Identifier{Text: "ErrorCodes"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "ErrorCodes"},
 						Body: &ast.ModuleBlock{
 							Statements: []ast.Stmt{
 								&ast.VariableStatement{
+									Modifiers: ast.ModifierExport,
 									DeclarationList: &ast.VariableDeclarationList{
 										Declarations: []*ast.VariableDeclaration{{
 											Name: &ast.Identifier{Text: "ParseError"},
@@ -555,6 +569,7 @@ cell will be synced.
 									LeadingComment: "Defined by JSON-RPC",
 								},
 								&ast.VariableStatement{
+									Modifiers: ast.ModifierExport,
 									DeclarationList: &ast.VariableDeclarationList{
 										Declarations: []*ast.VariableDeclaration{{
 											Name: &ast.Identifier{Text: "InvalidRequest"},
@@ -569,6 +584,7 @@ cell will be synced.
 									},
 								},
 								&ast.VariableStatement{
+									Modifiers: ast.ModifierExport,
 									DeclarationList: &ast.VariableDeclarationList{
 										Declarations: []*ast.VariableDeclaration{{
 											Name: &ast.Identifier{Text: "jsonrpcReservedErrorRangeStart"},
@@ -592,6 +608,7 @@ are left in the range.
 `),
 								},
 								&ast.VariableStatement{
+									Modifiers: ast.ModifierExport,
 									DeclarationList: &ast.VariableDeclarationList{
 										Declarations: []*ast.VariableDeclaration{{
 											Name: &ast.Identifier{Text: "serverErrorStart"},
@@ -616,10 +633,12 @@ are left in the range.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.ModuleDeclaration{
-						Name: &ast.Identifier{Text: "DiagnosticSeverity"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "DiagnosticSeverity"},
 						Body: &ast.ModuleBlock{
 							Statements: []ast.Stmt{
 								&ast.VariableStatement{
+									Modifiers: ast.ModifierExport,
 									DeclarationList: &ast.VariableDeclarationList{
 										Declarations: []*ast.VariableDeclaration{{
 											Name: &ast.Identifier{Text: "Error"},
@@ -632,6 +651,7 @@ are left in the range.
 									LeadingComment: "Reports an error.",
 								},
 								&ast.VariableStatement{
+									Modifiers: ast.ModifierExport,
 									DeclarationList: &ast.VariableDeclarationList{
 										Declarations: []*ast.VariableDeclaration{{
 											Name: &ast.Identifier{Text: "Warning"},
@@ -647,7 +667,8 @@ are left in the range.
 						},
 					},
 					&ast.TypeAliasDeclaration{
-						Name: &ast.Identifier{Text: "DiagnosticSeverity"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "DiagnosticSeverity"},
 						Type: &ast.UnionType{
 							Types: []ast.Type{
 								&ast.LiteralType{
@@ -667,7 +688,8 @@ are left in the range.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.TypeAliasDeclaration{
-						Name: &ast.Identifier{Text: "LSPObject"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "LSPObject"},
 						Type: &ast.TypeLiteral{
 							Members: []ast.Signature{
 								&ast.IndexSignature{
@@ -693,7 +715,8 @@ are left in the range.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.InterfaceDeclaration{
-						Name: &ast.Identifier{Text: "FullDocumentDiagnosticReport"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "FullDocumentDiagnosticReport"},
 						Members: []ast.Signature{
 							&ast.PropertySignature{
 								Name: &ast.Identifier{Text: "kind"},
@@ -714,9 +737,11 @@ are left in the range.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.InterfaceDeclaration{
-						Name: &ast.Identifier{Text: "SemanticTokensDelta"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "SemanticTokensDelta"},
 						Members: []ast.Signature{
 							&ast.PropertySignature{
+								Modifiers:     ast.ModifierReadonly,
 								Name:          &ast.Identifier{Text: "resultId"},
 								QuestionToken: true,
 								Type: &ast.TypeReference{
@@ -742,7 +767,8 @@ are left in the range.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.InterfaceDeclaration{
-						Name: &ast.Identifier{Text: "ParameterInformation"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "ParameterInformation"},
 						Members: []ast.Signature{
 							&ast.PropertySignature{
 								Name: &ast.Identifier{Text: "label"},
@@ -774,7 +800,8 @@ are left in the range.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.TypeAliasDeclaration{
-						Name: &ast.Identifier{Text: "NotebookDocumentFilter"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "NotebookDocumentFilter"},
 						Type: &ast.UnionType{
 							Types: []ast.Type{
 								&ast.TypeLiteral{
@@ -870,7 +897,8 @@ are left in the range.
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.TypeAliasDeclaration{
-						Name: &ast.Identifier{Text: "LSPAny"},
+						Modifiers: ast.ModifierExport,
+						Name:      &ast.Identifier{Text: "LSPAny"},
 						Type: &ast.UnionType{
 							Types: []ast.Type{
 								&ast.TypeReference{TypeName: &ast.Identifier{Text: "LSPObject"}},
@@ -897,7 +925,10 @@ are left in the range.
 				t.Fatal(err)
 			}
 
-			if got := parser.Parse(source); !reflect.DeepEqual(got, tt.want) {
+			got := parser.Parse(source)
+			asttest.ClearPositions(got)
+			got.Comments = nil // covered by TestParser_Comments
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("\ngot:\n%s\n\nwant:\n%s", printTreeStructure(got), printTreeStructure(tt.want))
 			}
 		})
@@ -916,6 +947,7 @@ func TestParser_Inline(t *testing.T) {
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.VariableStatement{
+						Modifiers: ast.ModifierExport,
 						DeclarationList: &ast.VariableDeclarationList{
 							Declarations: []*ast.VariableDeclaration{{
 								Name: &ast.Identifier{Text: "EOL"},
@@ -926,9 +958,9 @@ func TestParser_Inline(t *testing.T) {
 								},
 								Initializer: &ast.ArrayLiteralExpression{
 									Elements: []ast.Expr{
-										&ast.StringLiteral{Text: `\n`},
-										&ast.StringLiteral{Text: `\r\n`},
-										&ast.StringLiteral{Text: `\r`},
+										&ast.StringLiteral{Text: `\n`, Value: "\n", Quote: '\''},
+										&ast.StringLiteral{Text: `\r\n`, Value: "\r\n", Quote: '\''},
+										&ast.StringLiteral{Text: `\r`, Value: "\r", Quote: '\''},
 									},
 								},
 							}},
@@ -943,13 +975,14 @@ func TestParser_Inline(t *testing.T) {
 			want: &ast.SourceFile{
 				Statements: []ast.Stmt{
 					&ast.VariableStatement{
+						Modifiers: ast.ModifierExport,
 						DeclarationList: &ast.VariableDeclarationList{
 							Declarations: []*ast.VariableDeclaration{{
 								Name: &ast.Identifier{Text: "UTF8"},
 								Type: &ast.TypeReference{
 									TypeName: &ast.Identifier{Text: "string"},
 								},
-								Initializer: &ast.StringLiteral{Text: "utf-8"},
+								Initializer: &ast.StringLiteral{Text: "utf-8", Value: "utf-8", Quote: '\''},
 							}},
 						},
 					},
@@ -978,17 +1011,1705 @@ interface FileEvent {
 				},
 			},
 		},
+		{
+			name: "type alias in namespace",
+			src:  `namespace A { export type B = C; }`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.ModuleDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Body: &ast.ModuleBlock{
+							Statements: []ast.Stmt{
+								&ast.TypeAliasDeclaration{
+									Modifiers: ast.ModifierExport,
+									Name:      &ast.Identifier{Text: "B"},
+									Type: &ast.TypeReference{
+										TypeName: &ast.Identifier{Text: "C"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "function declaration",
+			src: `/** Overload. */
+export function get<T>(key: string, fallback?: T, ...rest: any[]): T;
+function get(key, n = -1)`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.FunctionDeclaration{
+						Modifiers:      ast.ModifierExport,
+						Name:           &ast.Identifier{Text: "get"},
+						TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+						Parameters: []*ast.Parameter{
+							{
+								Name: &ast.Identifier{Text: "key"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+							{
+								Name:          &ast.Identifier{Text: "fallback"},
+								QuestionToken: true,
+								Type:          &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+							},
+							{
+								DotDotDotToken: true,
+								Name:           &ast.Identifier{Text: "rest"},
+								Type: &ast.ArrayType{
+									ElementType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "any"}},
+								},
+							},
+						},
+						Type:           &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+						LeadingComment: "Overload.",
+					},
+					&ast.FunctionDeclaration{
+						Name: &ast.Identifier{Text: "get"},
+						Parameters: []*ast.Parameter{
+							{Name: &ast.Identifier{Text: "key"}},
+							{
+								Name: &ast.Identifier{Text: "n"},
+								Initializer: &ast.PrefixUnaryExpression{
+									Operator: token.Minus,
+									Operand:  &ast.NumericLiteral{Text: "1"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "function type",
+			src: `interface Handlers {
+	onError: (err: Error, retry?: boolean) => void;
+	onDone: () => string | number;
+	generic: <T>(value: T) => T;
+	grouped: (string)[];
+	callbacks: ((a) => void)[];
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "Handlers"},
+						Members: []ast.Signature{
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "onError"},
+								Type: &ast.FunctionType{
+									Parameters: []*ast.Parameter{
+										{
+											Name: &ast.Identifier{Text: "err"},
+											Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Error"}},
+										},
+										{
+											Name:          &ast.Identifier{Text: "retry"},
+											QuestionToken: true,
+											Type:          &ast.TypeReference{TypeName: &ast.Identifier{Text: "boolean"}},
+										},
+									},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+								},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "onDone"},
+								Type: &ast.FunctionType{
+									Type: &ast.UnionType{
+										Types: []ast.Type{
+											&ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+											&ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+										},
+									},
+								},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "generic"},
+								Type: &ast.FunctionType{
+									TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+									Parameters: []*ast.Parameter{{
+										Name: &ast.Identifier{Text: "value"},
+										Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+									}},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+								},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "grouped"},
+								Type: &ast.ArrayType{
+									ElementType: &ast.ParenthesizedType{
+										Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+									},
+								},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "callbacks"},
+								Type: &ast.ArrayType{
+									ElementType: &ast.ParenthesizedType{
+										Type: &ast.FunctionType{
+											Parameters: []*ast.Parameter{{Name: &ast.Identifier{Text: "a"}}},
+											Type:       &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "method, call and construct signatures",
+			src: `interface Foo {
+	/** Gets a value. */
+	get(key: string): Value; // Trailing
+	map?<T>(f: (v: Value) => T): T[];
+	(x: number): string;
+	<T>(x: T): T;
+	new (s: string): Foo;
+	new: boolean;
+	new(): void;
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "Foo"},
+						Members: []ast.Signature{
+							&ast.MethodSignature{
+								Name: &ast.Identifier{Text: "get"},
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "key"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+								}},
+								Type:            &ast.TypeReference{TypeName: &ast.Identifier{Text: "Value"}},
+								LeadingComment:  "Gets a value.",
+								TrailingComment: "Trailing",
+							},
+							&ast.MethodSignature{
+								Name:           &ast.Identifier{Text: "map"},
+								QuestionToken:  true,
+								TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "f"},
+									Type: &ast.FunctionType{
+										Parameters: []*ast.Parameter{{
+											Name: &ast.Identifier{Text: "v"},
+											Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Value"}},
+										}},
+										Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+									},
+								}},
+								Type: &ast.ArrayType{
+									ElementType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+								},
+							},
+							&ast.CallSignature{
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "x"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+								}},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+							&ast.CallSignature{
+								TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "x"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+								}},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+							},
+							&ast.ConstructSignature{
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "s"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+								}},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Foo"}},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "new"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "boolean"}},
+							},
+							&ast.ConstructSignature{
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "class declaration",
+			src: `/** A widget. */
+export declare abstract class Widget<T> extends Base implements Foo, Bar {
+	static readonly count: number;
+	private name?: string = 'w'; // Trailing
+	readonly [key: string]: T;
+	constructor(public readonly id: string, size?: number);
+	/** Draws the widget. */
+	protected abstract draw<U>(ctx: U): void;
+	get size(): number;
+	set size(value: number);
+	get: boolean;
+	static;
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.ClassDeclaration{
+						Modifiers:      ast.ModifierExport | ast.ModifierDeclare | ast.ModifierAbstract,
+						Name:           &ast.Identifier{Text: "Widget"},
+						TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+						HeritageClauses: []*ast.HeritageClause{
+							{
+								Token: token.Extends,
+								Types: []*ast.ExpressionWithTypeArguments{{Expression: &ast.Identifier{Text: "Base"}}},
+							},
+							{
+								Token: token.Implements,
+								Types: []*ast.ExpressionWithTypeArguments{{Expression: &ast.Identifier{Text: "Foo"}}},
+							},
+							{
+								Token: token.Implements,
+								Types: []*ast.ExpressionWithTypeArguments{{Expression: &ast.Identifier{Text: "Bar"}}},
+							},
+						},
+						Members: []ast.ClassElement{
+							&ast.PropertyDeclaration{
+								Modifiers: ast.ModifierStatic | ast.ModifierReadonly,
+								Name:      &ast.Identifier{Text: "count"},
+								Type:      &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+							},
+							&ast.PropertyDeclaration{
+								Modifiers:       ast.ModifierPrivate,
+								Name:            &ast.Identifier{Text: "name"},
+								QuestionToken:   true,
+								Type:            &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+								Initializer:     &ast.StringLiteral{Text: "w", Value: "w", Quote: '\''},
+								TrailingComment: "Trailing",
+							},
+							&ast.IndexSignature{
+								Modifiers: ast.ModifierReadonly,
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "key"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+								}},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+							},
+							&ast.Constructor{
+								Parameters: []*ast.Parameter{
+									{
+										Modifiers: ast.ModifierPublic | ast.ModifierReadonly,
+										Name:      &ast.Identifier{Text: "id"},
+										Type:      &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+									},
+									{
+										Name:          &ast.Identifier{Text: "size"},
+										QuestionToken: true,
+										Type:          &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+									},
+								},
+							},
+							&ast.MethodDeclaration{
+								Modifiers:      ast.ModifierProtected | ast.ModifierAbstract,
+								Name:           &ast.Identifier{Text: "draw"},
+								TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "U"}}},
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "ctx"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "U"}},
+								}},
+								Type:           &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+								LeadingComment: "Draws the widget.",
+							},
+							&ast.GetAccessor{
+								Name: &ast.Identifier{Text: "size"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+							},
+							&ast.SetAccessor{
+								Name: &ast.Identifier{Text: "size"},
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "value"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+								}},
+							},
+							&ast.PropertyDeclaration{
+								Name: &ast.Identifier{Text: "get"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "boolean"}},
+							},
+							&ast.PropertyDeclaration{
+								Name: &ast.Identifier{Text: "static"},
+							},
+						},
+						LeadingComment: "A widget.",
+					},
+				},
+			},
+		},
+		{
+			name: "import declarations",
+			src: `import 'polyfill';
+/** Types. */
+import type { A, B as C, type D } from './types';
+import E, * as ns from './ns';
+import type from './type';`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.ImportDeclaration{
+						ModuleSpecifier: &ast.StringLiteral{Text: "polyfill", Value: "polyfill", Quote: '\''},
+					},
+					&ast.ImportDeclaration{
+						ImportClause: &ast.ImportClause{
+							IsTypeOnly: true,
+							NamedBindings: &ast.NamedImports{
+								Elements: []*ast.ImportSpecifier{
+									{Name: &ast.Identifier{Text: "A"}},
+									{PropertyName: &ast.Identifier{Text: "B"}, Name: &ast.Identifier{Text: "C"}},
+									{IsTypeOnly: true, Name: &ast.Identifier{Text: "D"}},
+								},
+							},
+						},
+						ModuleSpecifier: &ast.StringLiteral{Text: "./types", Value: "./types", Quote: '\''},
+						LeadingComment:  "Types.",
+					},
+					&ast.ImportDeclaration{
+						ImportClause: &ast.ImportClause{
+							Name:          &ast.Identifier{Text: "E"},
+							NamedBindings: &ast.NamespaceImport{Name: &ast.Identifier{Text: "ns"}},
+						},
+						ModuleSpecifier: &ast.StringLiteral{Text: "./ns", Value: "./ns", Quote: '\''},
+					},
+					&ast.ImportDeclaration{
+						ImportClause: &ast.ImportClause{
+							Name: &ast.Identifier{Text: "type"},
+						},
+						ModuleSpecifier: &ast.StringLiteral{Text: "./type", Value: "./type", Quote: '\''},
+					},
+				},
+			},
+		},
+		{
+			name: "import specifiers named as",
+			src:  `import { type as, type as B, type as as C } from './x';`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.ImportDeclaration{
+						ImportClause: &ast.ImportClause{
+							NamedBindings: &ast.NamedImports{
+								Elements: []*ast.ImportSpecifier{
+									{IsTypeOnly: true, Name: &ast.Identifier{Text: "as"}},
+									{PropertyName: &ast.Identifier{Text: "type"}, Name: &ast.Identifier{Text: "B"}},
+									{IsTypeOnly: true, PropertyName: &ast.Identifier{Text: "as"}, Name: &ast.Identifier{Text: "C"}},
+								},
+							},
+						},
+						ModuleSpecifier: &ast.StringLiteral{Text: "./x", Value: "./x", Quote: '\''},
+					},
+				},
+			},
+		},
+		{
+			name: "export declarations",
+			src: `export { A, B as C };
+export type { D } from './d';
+export * from './all';
+export * as ns from './ns';
+export default A;
+export = ns;
+export default function (a: string): void;
+export default class extends Base {}
+declare const x: string;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.ExportDeclaration{
+						ExportClause: &ast.NamedExports{
+							Elements: []*ast.ExportSpecifier{
+								{Name: &ast.Identifier{Text: "A"}},
+								{PropertyName: &ast.Identifier{Text: "B"}, Name: &ast.Identifier{Text: "C"}},
+							},
+						},
+					},
+					&ast.ExportDeclaration{
+						IsTypeOnly: true,
+						ExportClause: &ast.NamedExports{
+							Elements: []*ast.ExportSpecifier{{Name: &ast.Identifier{Text: "D"}}},
+						},
+						ModuleSpecifier: &ast.StringLiteral{Text: "./d", Value: "./d", Quote: '\''},
+					},
+					&ast.ExportDeclaration{
+						ModuleSpecifier: &ast.StringLiteral{Text: "./all", Value: "./all", Quote: '\''},
+					},
+					&ast.ExportDeclaration{
+						ExportClause:    &ast.NamespaceExport{Name: &ast.Identifier{Text: "ns"}},
+						ModuleSpecifier: &ast.StringLiteral{Text: "./ns", Value: "./ns", Quote: '\''},
+					},
+					&ast.ExportAssignment{
+						Expression: &ast.TypeReference{TypeName: &ast.Identifier{Text: "A"}},
+					},
+					&ast.ExportAssignment{
+						IsExportEquals: true,
+						Expression:     &ast.TypeReference{TypeName: &ast.Identifier{Text: "ns"}},
+					},
+					&ast.FunctionDeclaration{
+						Modifiers: ast.ModifierExport | ast.ModifierDefault,
+						Parameters: []*ast.Parameter{{
+							Name: &ast.Identifier{Text: "a"},
+							Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+						}},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+					},
+					&ast.ClassDeclaration{
+						Modifiers: ast.ModifierExport | ast.ModifierDefault,
+						HeritageClauses: []*ast.HeritageClause{{
+							Token: token.Extends,
+							Types: []*ast.ExpressionWithTypeArguments{{Expression: &ast.Identifier{Text: "Base"}}},
+						}},
+					},
+					&ast.VariableStatement{
+						Modifiers: ast.ModifierDeclare,
+						DeclarationList: &ast.VariableDeclarationList{
+							Declarations: []*ast.VariableDeclaration{{
+								Name: &ast.Identifier{Text: "x"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "type arguments",
+			src: `interface A extends Base<T> {
+	a: Promise<Foo[]>;
+	b: Map<string, Array<T>>;
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						HeritageClauses: []*ast.HeritageClause{
+							{
+								Token: token.Extends,
+								Types: []*ast.ExpressionWithTypeArguments{{
+									Expression: &ast.Identifier{Text: "Base"},
+									TypeArguments: []ast.Type{
+										&ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+									},
+								}},
+							},
+						},
+						Members: []ast.Signature{
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "a"},
+								Type: &ast.TypeReference{
+									TypeName: &ast.Identifier{Text: "Promise"},
+									TypeArguments: []ast.Type{
+										&ast.ArrayType{
+											ElementType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Foo"}},
+										},
+									},
+								},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "b"},
+								Type: &ast.TypeReference{
+									TypeName: &ast.Identifier{Text: "Map"},
+									TypeArguments: []ast.Type{
+										&ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+										&ast.TypeReference{
+											TypeName: &ast.Identifier{Text: "Array"},
+											TypeArguments: []ast.Type{
+												&ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "type parameters",
+			src: `interface Foo<T extends Base = Default, in out U> {}
+type Pair<const K, V = K> = [K, V];`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "Foo"},
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Name:       &ast.Identifier{Text: "T"},
+								Constraint: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Base"}},
+								Default:    &ast.TypeReference{TypeName: &ast.Identifier{Text: "Default"}},
+							},
+							{
+								Modifiers: ast.ModifierIn | ast.ModifierOut,
+								Name:      &ast.Identifier{Text: "U"},
+							},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "Pair"},
+						TypeParameters: []*ast.TypeParameter{
+							{
+								Modifiers: ast.ModifierConst,
+								Name:      &ast.Identifier{Text: "K"},
+							},
+							{
+								Name:    &ast.Identifier{Text: "V"},
+								Default: &ast.TypeReference{TypeName: &ast.Identifier{Text: "K"}},
+							},
+						},
+						Type: &ast.TupleType{
+							Elements: []ast.Type{
+								&ast.TypeReference{TypeName: &ast.Identifier{Text: "K"}},
+								&ast.TypeReference{TypeName: &ast.Identifier{Text: "V"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "intersection types",
+			src: `type A = B & C | D & { e: string };
+type F =
+	| G
+	| H & I;
+type J = & K;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.UnionType{
+							Types: []ast.Type{
+								&ast.IntersectionType{
+									Types: []ast.Type{
+										&ast.TypeReference{TypeName: &ast.Identifier{Text: "B"}},
+										&ast.TypeReference{TypeName: &ast.Identifier{Text: "C"}},
+									},
+								},
+								&ast.IntersectionType{
+									Types: []ast.Type{
+										&ast.TypeReference{TypeName: &ast.Identifier{Text: "D"}},
+										&ast.TypeLiteral{
+											Members: []ast.Signature{
+												&ast.PropertySignature{
+													Name: &ast.Identifier{Text: "e"},
+													Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "F"},
+						Type: &ast.UnionType{
+							Types: []ast.Type{
+								&ast.TypeReference{TypeName: &ast.Identifier{Text: "G"}},
+								&ast.IntersectionType{
+									Types: []ast.Type{
+										&ast.TypeReference{TypeName: &ast.Identifier{Text: "H"}},
+										&ast.TypeReference{TypeName: &ast.Identifier{Text: "I"}},
+									},
+								},
+							},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "J"},
+						Type: &ast.IntersectionType{
+							Types: []ast.Type{
+								&ast.TypeReference{TypeName: &ast.Identifier{Text: "K"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "conditional types",
+			src: `type A<T> = T extends (...args: any[]) => infer R ? R : never;
+type B<T> = T extends [infer U extends string] ? U : T extends C ? D : E;
+type F<T> = T extends infer U extends G ? U : H;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name:           &ast.Identifier{Text: "A"},
+						TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+						Type: &ast.ConditionalType{
+							CheckType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+							ExtendsType: &ast.FunctionType{
+								Parameters: []*ast.Parameter{{
+									DotDotDotToken: true,
+									Name:           &ast.Identifier{Text: "args"},
+									Type:           &ast.ArrayType{ElementType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "any"}}},
+								}},
+								Type: &ast.InferType{
+									TypeParameter: &ast.TypeParameter{Name: &ast.Identifier{Text: "R"}},
+								},
+							},
+							TrueType:  &ast.TypeReference{TypeName: &ast.Identifier{Text: "R"}},
+							FalseType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "never"}},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name:           &ast.Identifier{Text: "B"},
+						TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+						Type: &ast.ConditionalType{
+							CheckType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+							ExtendsType: &ast.TupleType{
+								Elements: []ast.Type{
+									&ast.InferType{
+										TypeParameter: &ast.TypeParameter{
+											Name:       &ast.Identifier{Text: "U"},
+											Constraint: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+										},
+									},
+								},
+							},
+							TrueType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "U"}},
+							FalseType: &ast.ConditionalType{
+								CheckType:   &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+								ExtendsType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "C"}},
+								TrueType:    &ast.TypeReference{TypeName: &ast.Identifier{Text: "D"}},
+								FalseType:   &ast.TypeReference{TypeName: &ast.Identifier{Text: "E"}},
+							},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name:           &ast.Identifier{Text: "F"},
+						TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+						Type: &ast.ConditionalType{
+							CheckType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+							ExtendsType: &ast.InferType{
+								TypeParameter: &ast.TypeParameter{
+									Name:       &ast.Identifier{Text: "U"},
+									Constraint: &ast.TypeReference{TypeName: &ast.Identifier{Text: "G"}},
+								},
+							},
+							TrueType:  &ast.TypeReference{TypeName: &ast.Identifier{Text: "U"}},
+							FalseType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "H"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "tuple rest elements",
+			src:  `type A<T> = T extends [infer H, ...infer R] ? H : never;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name:           &ast.Identifier{Text: "A"},
+						TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+						Type: &ast.ConditionalType{
+							CheckType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+							ExtendsType: &ast.TupleType{
+								Elements: []ast.Type{
+									&ast.InferType{
+										TypeParameter: &ast.TypeParameter{Name: &ast.Identifier{Text: "H"}},
+									},
+									&ast.RestType{
+										Type: &ast.InferType{
+											TypeParameter: &ast.TypeParameter{Name: &ast.Identifier{Text: "R"}},
+										},
+									},
+								},
+							},
+							TrueType:  &ast.TypeReference{TypeName: &ast.Identifier{Text: "H"}},
+							FalseType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "never"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "mapped types",
+			src: `type A = { readonly [K in Keys]?: V };
+type B = { -readonly [P in K as Foo<P>]-?: X; };
+type C = { +readonly [P in K]+? };
+type D = { [key: string]: V };`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.MappedType{
+							ReadonlyToken: token.Readonly,
+							TypeParameter: &ast.TypeParameter{
+								Name:       &ast.Identifier{Text: "K"},
+								Constraint: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Keys"}},
+							},
+							QuestionToken: token.Question,
+							Type:          &ast.TypeReference{TypeName: &ast.Identifier{Text: "V"}},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Type: &ast.MappedType{
+							ReadonlyToken: token.Minus,
+							TypeParameter: &ast.TypeParameter{
+								Name:       &ast.Identifier{Text: "P"},
+								Constraint: &ast.TypeReference{TypeName: &ast.Identifier{Text: "K"}},
+							},
+							NameType: &ast.TypeReference{
+								TypeName:      &ast.Identifier{Text: "Foo"},
+								TypeArguments: []ast.Type{&ast.TypeReference{TypeName: &ast.Identifier{Text: "P"}}},
+							},
+							QuestionToken: token.Minus,
+							Type:          &ast.TypeReference{TypeName: &ast.Identifier{Text: "X"}},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "C"},
+						Type: &ast.MappedType{
+							ReadonlyToken: token.Plus,
+							TypeParameter: &ast.TypeParameter{
+								Name:       &ast.Identifier{Text: "P"},
+								Constraint: &ast.TypeReference{TypeName: &ast.Identifier{Text: "K"}},
+							},
+							QuestionToken: token.Plus,
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "D"},
+						Type: &ast.TypeLiteral{
+							Members: []ast.Signature{
+								&ast.IndexSignature{
+									Parameters: []*ast.Parameter{{
+										Name: &ast.Identifier{Text: "key"},
+										Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+									}},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "V"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "type operators",
+			src: `type A = keyof T | unique symbol;
+type B = readonly string[];
+type C = keyof typeof a.b.c<T>;
+type D = E.F.G;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.UnionType{
+							Types: []ast.Type{
+								&ast.TypeOperator{Operator: token.Keyof, Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}}},
+								&ast.TypeOperator{Operator: token.Unique, Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "symbol"}}},
+							},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Type: &ast.TypeOperator{
+							Operator: token.Readonly,
+							Type:     &ast.ArrayType{ElementType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}}},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "C"},
+						Type: &ast.TypeOperator{
+							Operator: token.Keyof,
+							Type: &ast.TypeQuery{
+								ExprName: &ast.QualifiedName{
+									Left: &ast.QualifiedName{
+										Left:  &ast.Identifier{Text: "a"},
+										Right: &ast.Identifier{Text: "b"},
+									},
+									Right: &ast.Identifier{Text: "c"},
+								},
+								TypeArguments: []ast.Type{&ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}}},
+							},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "D"},
+						Type: &ast.TypeReference{
+							TypeName: &ast.QualifiedName{
+								Left: &ast.QualifiedName{
+									Left:  &ast.Identifier{Text: "E"},
+									Right: &ast.Identifier{Text: "F"},
+								},
+								Right: &ast.Identifier{Text: "G"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "indexed access types",
+			src: `type A = T[][];
+type B = Foo['bar'] | Foo[number][];
+type C = Params['textDocument']['uri'];
+interface D {
+	a: string
+	[key: string]: any
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.ArrayType{
+							ElementType: &ast.ArrayType{ElementType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}}},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Type: &ast.UnionType{
+							Types: []ast.Type{
+								&ast.IndexedAccessType{
+									ObjectType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Foo"}},
+									IndexType:  &ast.LiteralType{Literal: &ast.StringLiteral{Text: "bar", Value: "bar", Quote: '\''}},
+								},
+								&ast.ArrayType{
+									ElementType: &ast.IndexedAccessType{
+										ObjectType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Foo"}},
+										IndexType:  &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+									},
+								},
+							},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "C"},
+						Type: &ast.IndexedAccessType{
+							ObjectType: &ast.IndexedAccessType{
+								ObjectType: &ast.TypeReference{TypeName: &ast.Identifier{Text: "Params"}},
+								IndexType:  &ast.LiteralType{Literal: &ast.StringLiteral{Text: "textDocument", Value: "textDocument", Quote: '\''}},
+							},
+							IndexType: &ast.LiteralType{Literal: &ast.StringLiteral{Text: "uri", Value: "uri", Quote: '\''}},
+						},
+					},
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "D"},
+						Members: []ast.Signature{
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "a"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+							&ast.IndexSignature{
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "key"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+								}},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "any"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "template literals",
+			src: "type A = `on${Capitalize<Name>}Change`;\n" +
+				"type B = `${A}` | `plain`;\n" +
+				"const c = `a${1}b${'x'}c`;",
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.TemplateLiteralType{
+							Head: "on",
+							TemplateSpans: []*ast.TemplateLiteralTypeSpan{{
+								Type: &ast.TypeReference{
+									TypeName:      &ast.Identifier{Text: "Capitalize"},
+									TypeArguments: []ast.Type{&ast.TypeReference{TypeName: &ast.Identifier{Text: "Name"}}},
+								},
+								Literal: "Change",
+							}},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Type: &ast.UnionType{
+							Types: []ast.Type{
+								&ast.TemplateLiteralType{
+									TemplateSpans: []*ast.TemplateLiteralTypeSpan{{
+										Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "A"}},
+									}},
+								},
+								&ast.LiteralType{Literal: &ast.NoSubstitutionTemplateLiteral{Text: "plain"}},
+							},
+						},
+					},
+					&ast.VariableStatement{
+						DeclarationList: &ast.VariableDeclarationList{
+							Declarations: []*ast.VariableDeclaration{{
+								Name: &ast.Identifier{Text: "c"},
+								Initializer: &ast.TemplateExpression{
+									Head: "a",
+									TemplateSpans: []*ast.TemplateSpan{
+										{Expression: &ast.NumericLiteral{Text: "1"}, Literal: "b"},
+										{Expression: &ast.StringLiteral{Text: "x", Value: "x", Quote: '\''}, Literal: "c"},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "string escapes",
+			src:  `type A = "it's" | 'say \'hi\'' | '\u{1F600}\n';`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.UnionType{
+							Types: []ast.Type{
+								&ast.LiteralType{Literal: &ast.StringLiteral{Text: "it's", Value: "it's", Quote: '"'}},
+								&ast.LiteralType{Literal: &ast.StringLiteral{Text: `say \'hi\'`, Value: "say 'hi'", Quote: '\''}},
+								&ast.LiteralType{Literal: &ast.StringLiteral{Text: `\u{1F600}\n`, Value: "\U0001F600\n", Quote: '\''}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "numeric literals",
+			src: `export const MinInteger = -2147483648;
+const a = 0xFF_FF;
+type B = 1.5e3 | 10n | -2147483648 | -1n;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.VariableStatement{
+						Modifiers: ast.ModifierExport,
+						DeclarationList: &ast.VariableDeclarationList{
+							Declarations: []*ast.VariableDeclaration{{
+								Name: &ast.Identifier{Text: "MinInteger"},
+								Initializer: &ast.PrefixUnaryExpression{
+									Operator: token.Minus,
+									Operand:  &ast.NumericLiteral{Text: "2147483648"},
+								},
+							}},
+						},
+					},
+					&ast.VariableStatement{
+						DeclarationList: &ast.VariableDeclarationList{
+							Declarations: []*ast.VariableDeclaration{{
+								Name:        &ast.Identifier{Text: "a"},
+								Initializer: &ast.NumericLiteral{Text: "0xFF_FF"},
+							}},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Type: &ast.UnionType{
+							Types: []ast.Type{
+								&ast.LiteralType{Literal: &ast.NumericLiteral{Text: "1.5e3"}},
+								&ast.LiteralType{Literal: &ast.BigIntLiteral{Text: "10n"}},
+								&ast.LiteralType{Literal: &ast.PrefixUnaryExpression{
+									Operator: token.Minus,
+									Operand:  &ast.NumericLiteral{Text: "2147483648"},
+								}},
+								&ast.LiteralType{Literal: &ast.PrefixUnaryExpression{
+									Operator: token.Minus,
+									Operand:  &ast.BigIntLiteral{Text: "1n"},
+								}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "regular expressions",
+			src: `export const Pattern = /^[/\]]+\/$/gi;
+const a = /=/;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.VariableStatement{
+						Modifiers: ast.ModifierExport,
+						DeclarationList: &ast.VariableDeclarationList{
+							Declarations: []*ast.VariableDeclaration{{
+								Name:        &ast.Identifier{Text: "Pattern"},
+								Initializer: &ast.RegularExpressionLiteral{Pattern: `^[/\]]+\/$`, Flags: "gi"},
+							}},
+						},
+					},
+					&ast.VariableStatement{
+						DeclarationList: &ast.VariableDeclarationList{
+							Declarations: []*ast.VariableDeclaration{{
+								Name:        &ast.Identifier{Text: "a"},
+								Initializer: &ast.RegularExpressionLiteral{Pattern: "="},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unicode identifiers",
+			src: `interface Schema {
+	$ref: string
+	_internal: número
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "Schema"},
+						Members: []ast.Signature{
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "$ref"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "_internal"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "número"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "keywords as names",
+			src: `interface A {
+	type: string
+	readonly: boolean
+	namespace?: string
+	default: this
+	readonly readonly: void
+	new: number
+	get(): void
+}
+class B {
+	static get: number
+	declare(type: string): void
+}
+enum C { default, class }`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Members: []ast.Signature{
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "type"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "readonly"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "boolean"}},
+							},
+							&ast.PropertySignature{
+								Name:          &ast.Identifier{Text: "namespace"},
+								QuestionToken: true,
+								Type:          &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "default"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "this"}},
+							},
+							&ast.PropertySignature{
+								Modifiers: ast.ModifierReadonly,
+								Name:      &ast.Identifier{Text: "readonly"},
+								Type:      &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "new"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+							},
+							&ast.MethodSignature{
+								Name: &ast.Identifier{Text: "get"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+							},
+						},
+					},
+					&ast.ClassDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Members: []ast.ClassElement{
+							&ast.PropertyDeclaration{
+								Modifiers: ast.ModifierStatic,
+								Name:      &ast.Identifier{Text: "get"},
+								Type:      &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+							},
+							&ast.MethodDeclaration{
+								Name: &ast.Identifier{Text: "declare"},
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "type"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+								}},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+							},
+						},
+					},
+					&ast.EnumDeclaration{
+						Name: &ast.Identifier{Text: "C"},
+						Members: []*ast.EnumMember{
+							{Name: &ast.Identifier{Text: "default"}},
+							{Name: &ast.Identifier{Text: "class"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "nested type arguments",
+			src: `type A = Map<string, Array<Set<T>>>;
+type B<T = C<D>> = T;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.TypeReference{
+							TypeName: &ast.Identifier{Text: "Map"},
+							TypeArguments: []ast.Type{
+								&ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+								&ast.TypeReference{
+									TypeName: &ast.Identifier{Text: "Array"},
+									TypeArguments: []ast.Type{
+										&ast.TypeReference{
+											TypeName:      &ast.Identifier{Text: "Set"},
+											TypeArguments: []ast.Type{&ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}}},
+										},
+									},
+								},
+							},
+						},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						TypeParameters: []*ast.TypeParameter{{
+							Name: &ast.Identifier{Text: "T"},
+							Default: &ast.TypeReference{
+								TypeName:      &ast.Identifier{Text: "C"},
+								TypeArguments: []ast.Type{&ast.TypeReference{TypeName: &ast.Identifier{Text: "D"}}},
+							},
+						}},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+					},
+				},
+			},
+		},
+		{
+			name: "empty leading block comment",
+			src:  `/**/ type A = B;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "B"}},
+					},
+				},
+			},
+		},
+		{
+			name: "generic function type argument",
+			src:  `type A = B<<T>(value: T) => void>;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.TypeReference{
+							TypeName: &ast.Identifier{Text: "B"},
+							TypeArguments: []ast.Type{
+								&ast.FunctionType{
+									TypeParameters: []*ast.TypeParameter{{Name: &ast.Identifier{Text: "T"}}},
+									Parameters: []*ast.Parameter{{
+										Name: &ast.Identifier{Text: "value"},
+										Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "T"}},
+									}},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "void"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "readonly index signature",
+			src:  `interface Foo { readonly [key: string]: number; }`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "Foo"},
+						Members: []ast.Signature{
+							&ast.IndexSignature{
+								Modifiers: ast.ModifierReadonly,
+								Parameters: []*ast.Parameter{{
+									Name: &ast.Identifier{Text: "key"},
+									Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+								}},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parser.Parse([]byte(tt.src))
+			asttest.ClearPositions(got)
+			got.Comments = nil // covered by TestParser_Comments
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\ngot:\n%s\n\nwant:\n%s", printTreeStructure(got), printTreeStructure(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseFile_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		src          string
+		wantErr      string
+		wantToken    token.Token
+		wantExpected []token.Kind
+	}{
+		{
+			name:      "unknown statement",
+			src:       "export let foo: string;",
+			wantErr:   `1:8: unexpected keyword "let"`,
+			wantToken: token.Token{Kind: token.Let, Text: "let"},
+		},
+		{
+			name:         "missing colon",
+			src:          "interface Foo {\n\tbar string;\n}",
+			wantErr:      `2:6: expected :, got Ident "string"`,
+			wantToken:    token.Token{Kind: token.Ident, Text: "string"},
+			wantExpected: []token.Kind{token.Colon},
+		},
+		{
+			name:         "bad type",
+			src:          "type Foo = ;",
+			wantErr:      "1:12: expected one of Ident, {, (, <, [, String, Number, BigInt, -, NoSubstitutionTemplate, TemplateHead, got ;",
+			wantToken:    token.Token{Kind: token.Semicolon},
+			wantExpected: []token.Kind{token.Ident, token.LBrace, token.LParen, token.LAngle, token.LBrack, token.String, token.Number, token.BigInt, token.Minus, token.NoSubstitutionTemplate, token.TemplateHead},
+		},
+		{
+			name:         "missing from",
+			src:          "import { A } form './a';",
+			wantErr:      `1:14: expected "from", got Ident "form"`,
+			wantToken:    token.Token{Kind: token.Ident, Text: "form"},
+			wantExpected: []token.Kind{token.From},
+		},
+		{
+			name:      "unterminated string",
+			src:       `const x = "abc`,
+			wantErr:   "1:11: string literal not terminated",
+			wantToken: token.Token{Kind: token.Illegal},
+		},
+		{
+			name:         "unexpected EOF",
+			src:          "interface Foo {",
+			wantErr:      "1:16: expected one of Ident, [, (, <, got EOF",
+			wantToken:    token.Token{Kind: token.EOF},
+			wantExpected: []token.Kind{token.Ident, token.LBrack, token.LParen, token.LAngle},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.ParseFile([]byte(tt.src), 0)
+			if got != nil {
+				t.Errorf("got file %v, want nil", got)
+			}
+			if err == nil {
+				t.Fatal("got nil error")
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("got error %q, want %q", err, tt.wantErr)
+			}
+
+			var perr *parser.Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("got error of type %T, want *parser.Error", err)
+			}
+			if perr.Pos != perr.Token.Pos {
+				t.Errorf("got position %s, want token position %s", perr.Pos, perr.Token.Pos)
+			}
+			if gotToken := (token.Token{Kind: perr.Token.Kind, Text: perr.Token.Text}); gotToken != tt.wantToken {
+				t.Errorf("got token %v, want %v", gotToken, tt.wantToken)
+			}
+			if !reflect.DeepEqual(perr.Expected, tt.wantExpected) {
+				t.Errorf("got expected kinds %v, want %v", perr.Expected, tt.wantExpected)
+			}
+		})
+	}
+}
+
+func TestParseFile_RecoverErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		want     *ast.SourceFile
+		wantErrs []string
+	}{
+		{
+			name: "unknown statement",
+			src: `type A = string;
+export let foo: string;
+interface B {}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+					},
+					&ast.BadStmt{},
+					&ast.InterfaceDeclaration{Name: &ast.Identifier{Text: "B"}},
+				},
+			},
+			wantErrs: []string{`2:8: unexpected keyword "let"`},
+		},
+		{
+			name: "scanner error in unknown statement",
+			src: `let a = "\u{zz}";
+type B = string;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.BadStmt{},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+					},
+				},
+			},
+			wantErrs: []string{
+				`1:1: unexpected keyword "let"`,
+				"1:9: invalid escape sequence in string literal",
+			},
+		},
+		{
+			name: "unknown statement with braces",
+			src: `if (foo) { bar: string; }
+type A = string;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.BadStmt{},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+					},
+				},
+			},
+			wantErrs: []string{`1:1: unexpected keyword "if"`},
+		},
+		{
+			name: "inside namespace",
+			src: `namespace N {
+	let foo: string;
+	export const a: string = 'a';
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.ModuleDeclaration{
+						Name: &ast.Identifier{Text: "N"},
+						Body: &ast.ModuleBlock{
+							Statements: []ast.Stmt{
+								&ast.BadStmt{},
+								&ast.VariableStatement{
+									Modifiers: ast.ModifierExport,
+									DeclarationList: &ast.VariableDeclarationList{
+										Declarations: []*ast.VariableDeclaration{{
+											Name:        &ast.Identifier{Text: "a"},
+											Type:        &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+											Initializer: &ast.StringLiteral{Text: "a", Value: "a", Quote: '\''},
+										}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErrs: []string{`2:2: unexpected keyword "let"`},
+		},
+		{
+			name: "unclosed parenthesis",
+			src: `interface A {
+  a(x: string;
+}
+
+interface B { b: string; }
+
+type C = (;
+type D = E;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.BadStmt{},
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Members: []ast.Signature{
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "b"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+						},
+					},
+					&ast.BadStmt{},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "D"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "E"}},
+					},
+				},
+			},
+			wantErrs: []string{
+				"2:14: expected ), got ;",
+				"7:11: expected ), got ;",
+			},
+		},
+		{
+			name: "bad type inside bad statement",
+			src: `type A = { a: ) };
+type B = C;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.BadStmt{},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "C"}},
+					},
+				},
+			},
+			wantErrs: []string{"1:15: expected one of Ident, [, (, <, got )"},
+		},
+		{
+			name: "unsupported statement keywords",
+			src: `let a = b
+var c = d
+type E = F;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.BadStmt{},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "E"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "F"}},
+					},
+				},
+			},
+			wantErrs: []string{`1:1: unexpected keyword "let"`},
+		},
+		{
+			name: "bad type before member on next line",
+			src: `interface A {
+	a: @
+	b: string;
+	c: number;
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Members: []ast.Signature{
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "a"},
+								Type: &ast.BadType{},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "b"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "c"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "number"}},
+							},
+						},
+					},
+				},
+			},
+			wantErrs: []string{
+				"2:5: expected one of Ident, {, (, <, [, String, Number, BigInt, -, NoSubstitutionTemplate, TemplateHead, got @",
+			},
+		},
+		{
+			name: "bad type before statement",
+			src: `type A = @
+type B = C;
+type D = E;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.BadType{},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "B"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "C"}},
+					},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "D"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "E"}},
+					},
+				},
+			},
+			wantErrs: []string{
+				"1:10: expected one of Ident, {, (, <, [, String, Number, BigInt, -, NoSubstitutionTemplate, TemplateHead, got @",
+			},
+		},
+		{
+			name: "contextual keyword as identifier in unknown statement",
+			src: `var type = 1;
+type A = string;`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.BadStmt{},
+					&ast.TypeAliasDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+					},
+				},
+			},
+			wantErrs: []string{`1:1: unexpected keyword "var"`},
+		},
+		{
+			name: "bad types",
+			src: `interface A {
+	a: *;
+	b: string;
+	c: (¤);
+}`,
+			want: &ast.SourceFile{
+				Statements: []ast.Stmt{
+					&ast.InterfaceDeclaration{
+						Name: &ast.Identifier{Text: "A"},
+						Members: []ast.Signature{
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "a"},
+								Type: &ast.BadType{},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "b"},
+								Type: &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+							},
+							&ast.PropertySignature{
+								Name: &ast.Identifier{Text: "c"},
+								Type: &ast.ParenthesizedType{Type: &ast.BadType{}},
+							},
+						},
+					},
+				},
+			},
+			wantErrs: []string{
+				"2:5: expected one of Ident, {, (, <, [, String, Number, BigInt, -, NoSubstitutionTemplate, TemplateHead, got *",
+				"4:6: illegal character U+00A4 '¤'",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := parser.Parse([]byte(tt.src)); !reflect.DeepEqual(got, tt.want) {
+			got, err := parser.ParseFile([]byte(tt.src), parser.RecoverErrors)
+
+			var errs parser.ErrorList
+			if !errors.As(err, &errs) {
+				t.Fatalf("got error %v, want parser.ErrorList", err)
+			}
+			var gotErrs []string
+			for _, e := range errs {
+				gotErrs = append(gotErrs, e.Error())
+			}
+			if !reflect.DeepEqual(gotErrs, tt.wantErrs) {
+				t.Errorf("got errors %q, want %q", gotErrs, tt.wantErrs)
+			}
+
+			asttest.ClearPositions(got)
+			got.Comments = nil // covered by TestParser_Comments
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("\ngot:\n%s\n\nwant:\n%s", printTreeStructure(got), printTreeStructure(tt.want))
 			}
 		})
 	}
 }
 
+func TestParseFile_RecoverErrors_BadStmtRange(t *testing.T) {
+	src := "let foo: string;\ntype A = string;"
+	got, _ := parser.ParseFile([]byte(src), parser.RecoverErrors)
+
+	bad := got.Statements[0]
+	if gotRange := fmt.Sprintf("%s-%s", bad.Pos(), bad.End()); gotRange != "1:1-1:17" {
+		t.Errorf("got range %s, want 1:1-1:17", gotRange)
+	}
+}
+
+func TestParser_Positions(t *testing.T) {
+	src := `export type A = string;
+
+interface Foo {
+	bar?: B.C[];
+}`
+
+	var got []string
+	ast.Inspect(parser.Parse([]byte(src)), func(node ast.Node) bool {
+		if node != nil {
+			got = append(got, fmt.Sprintf("%T %s-%s", node, node.Pos(), node.End()))
+		}
+		return true
+	})
+
+	want := []string{
+		"*ast.SourceFile 1:1-5:2",
+		"*ast.TypeAliasDeclaration 1:1-1:24",
+		"*ast.Identifier 1:13-1:14",
+		"*ast.TypeReference 1:17-1:23",
+		"*ast.Identifier 1:17-1:23",
+		"*ast.InterfaceDeclaration 3:1-5:2",
+		"*ast.Identifier 3:11-3:14",
+		"*ast.PropertySignature 4:2-4:14",
+		"*ast.Identifier 4:2-4:5",
+		"*ast.ArrayType 4:8-4:13",
+		"*ast.TypeReference 4:8-4:11",
+		"*ast.QualifiedName 4:8-4:11",
+		"*ast.Identifier 4:8-4:9",
+		"*ast.Identifier 4:10-4:11",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot:\n%s\n\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParser_Comments(t *testing.T) {
+	src := `// a
+// b
+
+/**
+ * c
+ */
+interface Foo { // d
+	[key: /* e */ string]: A | /* f */ B; /* g */ /* h */
+	// i
+}`
+
+	var got []string
+	for _, group := range parser.Parse([]byte(src)).Comments {
+		var texts []string
+		for _, c := range group.List {
+			texts = append(texts, fmt.Sprintf("%s %s-%s", c.Text, c.Pos(), c.End()))
+		}
+		got = append(got, strings.Join(texts, " + "))
+	}
+
+	want := []string{
+		"// a 1:1-1:5 + // b 2:1-2:5",
+		"/**\n * c\n */ 4:1-6:4",
+		"// d 7:17-7:21",
+		"/* e */ 8:8-8:15",
+		"/* f */ 8:29-8:36",
+		"/* g */ 8:40-8:47 + /* h */ 8:48-8:55",
+		"// i 9:2-9:6",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot:\n%s\n\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
 func printTreeStructure(node ast.Node) string {
 	if node == nil {
 		return ""
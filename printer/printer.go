@@ -0,0 +1,1006 @@
+// Package printer implements printing of AST nodes as TypeScript source code.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/armsnyder/typescript-ast-go/ast"
+	"github.com/armsnyder/typescript-ast-go/token"
+)
+
+// Config controls the output of Fprint.
+type Config struct {
+	Indent string // string used for each level of indentation; a tab if empty
+}
+
+// Fprint prints the TypeScript source code of an AST node to output, using
+// the default configuration.
+func Fprint(output io.Writer, node ast.Node) error {
+	return (&Config{}).Fprint(output, node)
+}
+
+// Fprint prints the TypeScript source code of an AST node to output.
+//
+// Leading comments are printed as JSDoc comments, and trailing comments are
+// printed as line comments, or as block comments if they span several lines.
+// Any */ in the text of a block comment is escaped as *\/. Other comments,
+// such as those recorded only in the Comments of an [ast.SourceFile], are not
+// printed.
+func (cfg *Config) Fprint(output io.Writer, node ast.Node) error {
+	p := printer{indentString: cfg.Indent}
+	if p.indentString == "" {
+		p.indentString = "\t"
+	}
+
+	if err := p.print(node); err != nil {
+		return err
+	}
+
+	if _, ok := node.(*ast.SourceFile); ok {
+		p.buf.WriteByte('\n')
+	}
+
+	_, err := output.Write(p.buf.Bytes())
+	return err
+}
+
+type printer struct {
+	indentString string
+	buf          bytes.Buffer
+	indent       int
+}
+
+func (p *printer) print(node ast.Node) error { //nolint:revive // cyclomatic
+	switch n := node.(type) {
+	// Expressions.
+	case *ast.NumericLiteral:
+		p.write(n.Text)
+	case *ast.BigIntLiteral:
+		p.write(n.Text)
+	case *ast.StringLiteral:
+		quote := "'"
+		if n.Quote == '"' {
+			quote = `"`
+		}
+		p.write(quote, n.Text, quote)
+	case *ast.RegularExpressionLiteral:
+		p.write("/", n.Pattern, "/", n.Flags)
+	case *ast.NoSubstitutionTemplateLiteral:
+		p.write("`", n.Text, "`")
+	case *ast.TemplateExpression:
+		p.write("`", n.Head)
+		if err := printList(p, n.TemplateSpans, ""); err != nil {
+			return err
+		}
+		p.write("`")
+	case *ast.TemplateSpan:
+		return p.printAll("${", n.Expression, "}", n.Literal)
+	case *ast.Identifier:
+		p.write(n.Text)
+	case *ast.QualifiedName:
+		return p.printAll("", n.Left, ".", n.Right)
+	case *ast.ArrayLiteralExpression:
+		p.write("[")
+		if err := printList(p, n.Elements, ", "); err != nil {
+			return err
+		}
+		p.write("]")
+	case *ast.EnumMember:
+		return p.printEnumMember(n)
+	case *ast.TypeParameter:
+		return p.printTypeParameter(n)
+	case *ast.HeritageClause:
+		p.write(heritageKeyword(n), " ")
+		return printList(p, n.Types, ", ")
+	case *ast.ExpressionWithTypeArguments:
+		if err := p.print(n.Expression); err != nil {
+			return err
+		}
+		return p.printTypeArguments(n.TypeArguments)
+	case *ast.PropertySignature:
+		return p.printPropertySignature(n)
+	case *ast.IndexSignature:
+		return p.printIndexSignature(n)
+	case *ast.MethodSignature:
+		return p.printMethodSignature(n)
+	case *ast.CallSignature:
+		p.leadingComment(n.LeadingComment)
+		return p.printCallLikeSignature("", n.TypeParameters, n.Parameters, n.Type, n.TrailingComment)
+	case *ast.ConstructSignature:
+		p.leadingComment(n.LeadingComment)
+		return p.printCallLikeSignature("new ", n.TypeParameters, n.Parameters, n.Type, n.TrailingComment)
+	case *ast.Parameter:
+		return p.printParameter(n)
+	case *ast.PropertyDeclaration:
+		return p.printPropertyDeclaration(n)
+	case *ast.MethodDeclaration:
+		return p.printMethodDeclaration(n)
+	case *ast.Constructor:
+		p.leadingComment(n.LeadingComment)
+		p.modifiers(n.Modifiers)
+		return p.printCallLikeSignature("constructor", nil, n.Parameters, nil, n.TrailingComment)
+	case *ast.GetAccessor:
+		p.leadingComment(n.LeadingComment)
+		p.modifiers(n.Modifiers)
+		if err := p.printAll("get ", n.Name); err != nil {
+			return err
+		}
+		return p.printCallLikeSignature("", nil, n.Parameters, n.Type, n.TrailingComment)
+	case *ast.SetAccessor:
+		p.leadingComment(n.LeadingComment)
+		p.modifiers(n.Modifiers)
+		if err := p.printAll("set ", n.Name); err != nil {
+			return err
+		}
+		return p.printCallLikeSignature("", nil, n.Parameters, nil, n.TrailingComment)
+	case *ast.VariableDeclarationList:
+		return printList(p, n.Declarations, ", ")
+	case *ast.VariableDeclaration:
+		return p.printVariableDeclaration(n)
+	case *ast.PrefixUnaryExpression:
+		p.write(n.Operator.String())
+		return p.print(n.Operand)
+	case *ast.ImportClause:
+		return p.printImportClause(n)
+	case *ast.NamespaceImport:
+		return p.printAll("* as ", n.Name)
+	case *ast.NamedImports:
+		return printNamedBindings(p, n.Elements)
+	case *ast.ImportSpecifier:
+		return p.printSpecifier(n.IsTypeOnly, n.PropertyName, n.Name)
+	case *ast.NamespaceExport:
+		return p.printAll("* as ", n.Name)
+	case *ast.NamedExports:
+		return printNamedBindings(p, n.Elements)
+	case *ast.ExportSpecifier:
+		return p.printSpecifier(n.IsTypeOnly, n.PropertyName, n.Name)
+
+	// Types.
+	case *ast.LiteralType:
+		return p.print(n.Literal)
+	case *ast.TypeLiteral:
+		return p.printTypeLiteral(n)
+	case *ast.ArrayType:
+		return p.printArrayType(n)
+	case *ast.TypeReference:
+		if err := p.print(n.TypeName); err != nil {
+			return err
+		}
+		return p.printTypeArguments(n.TypeArguments)
+	case *ast.UnionType:
+		return p.printUnionType(n)
+	case *ast.IntersectionType:
+		return p.printIntersectionType(n)
+	case *ast.ConditionalType:
+		return p.printConditionalType(n)
+	case *ast.MappedType:
+		return p.printMappedType(n)
+	case *ast.IndexedAccessType:
+		if err := p.printType(n.ObjectType, precPostfix); err != nil {
+			return err
+		}
+		return p.printAll("[", n.IndexType, "]")
+	case *ast.TypeOperator:
+		p.write(n.Operator.String(), " ")
+		return p.printType(n.Type, precOperator)
+	case *ast.TypeQuery:
+		if err := p.printAll("typeof ", n.ExprName); err != nil {
+			return err
+		}
+		return p.printTypeArguments(n.TypeArguments)
+	case *ast.InferType:
+		return p.printInferType(n)
+	case *ast.TemplateLiteralType:
+		p.write("`", n.Head)
+		if err := printList(p, n.TemplateSpans, ""); err != nil {
+			return err
+		}
+		p.write("`")
+	case *ast.TemplateLiteralTypeSpan:
+		return p.printAll("${", n.Type, "}", n.Literal)
+	case *ast.TupleType:
+		p.write("[")
+		if err := printList(p, n.Elements, ", "); err != nil {
+			return err
+		}
+		p.write("]")
+	case *ast.RestType:
+		return p.printAll("...", n.Type)
+	case *ast.ParenthesizedType:
+		return p.printAll("(", n.Type, ")")
+	case *ast.FunctionType:
+		return p.printFunctionType(n)
+
+	// Statements.
+	case *ast.SourceFile:
+		return p.printStatements(n.Statements)
+	case *ast.ModuleBlock:
+		return p.printBlock(n.Statements)
+	case *ast.VariableStatement:
+		p.leadingComment(n.LeadingComment)
+		p.modifiers(n.Modifiers)
+		return p.printAll("const ", n.DeclarationList, ";")
+	case *ast.TypeAliasDeclaration:
+		p.leadingComment(n.LeadingComment)
+		p.modifiers(n.Modifiers)
+		if err := p.printAll("type ", n.Name); err != nil {
+			return err
+		}
+		if err := p.printTypeParameters(n.TypeParameters); err != nil {
+			return err
+		}
+		return p.printAll(" = ", n.Type, ";")
+	case *ast.EnumDeclaration:
+		return p.printEnumDeclaration(n)
+	case *ast.InterfaceDeclaration:
+		return p.printInterfaceDeclaration(n)
+	case *ast.ClassDeclaration:
+		return p.printClassDeclaration(n)
+	case *ast.FunctionDeclaration:
+		return p.printFunctionDeclaration(n)
+	case *ast.ModuleDeclaration:
+		p.leadingComment(n.LeadingComment)
+		p.modifiers(n.Modifiers)
+		return p.printAll("namespace ", n.Name, " ", n.Body)
+	case *ast.ImportDeclaration:
+		return p.printImportDeclaration(n)
+	case *ast.ExportDeclaration:
+		return p.printExportDeclaration(n)
+	case *ast.ExportAssignment:
+		p.leadingComment(n.LeadingComment)
+		if n.IsExportEquals {
+			return p.printAll("export = ", n.Expression, ";")
+		}
+		return p.printAll("export default ", n.Expression, ";")
+
+	case *ast.BadType, *ast.BadStmt:
+		return fmt.Errorf("printer: cannot print %T in place of invalid source", node)
+
+	default:
+		return fmt.Errorf("printer: unsupported node type %T", node)
+	}
+
+	return nil
+}
+
+// printAll prints a sequence of strings and nodes.
+func (p *printer) printAll(parts ...any) error {
+	for _, part := range parts {
+		switch part := part.(type) {
+		case string:
+			p.write(part)
+		case ast.Node:
+			if err := p.print(part); err != nil {
+				return err
+			}
+		default:
+			panic(fmt.Sprintf("printer: unexpected part %T", part))
+		}
+	}
+	return nil
+}
+
+func printList[T ast.Node](p *printer, nodes []T, sep string) error {
+	for i, node := range nodes {
+		if i > 0 {
+			p.write(sep)
+		}
+		if err := p.print(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) printStatements(stmts []ast.Stmt) error {
+	for i, stmt := range stmts {
+		if i > 0 {
+			p.blankLine()
+		}
+		if err := p.print(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) printBlock(stmts []ast.Stmt) error {
+	p.write("{")
+	if len(stmts) > 0 {
+		p.indent++
+		p.linebreak()
+		if err := p.printStatements(stmts); err != nil {
+			return err
+		}
+		p.indent--
+		p.linebreak()
+	}
+	p.write("}")
+	return nil
+}
+
+func (p *printer) printVariableDeclaration(n *ast.VariableDeclaration) error {
+	if err := p.print(n.Name); err != nil {
+		return err
+	}
+	if n.Type != nil {
+		if err := p.printAll(": ", n.Type); err != nil {
+			return err
+		}
+	}
+	if n.Initializer != nil {
+		return p.printAll(" = ", n.Initializer)
+	}
+	return nil
+}
+
+func (p *printer) printImportDeclaration(n *ast.ImportDeclaration) error {
+	p.leadingComment(n.LeadingComment)
+	p.write("import ")
+	if n.ImportClause != nil {
+		if err := p.printAll(n.ImportClause, " from "); err != nil {
+			return err
+		}
+	}
+	return p.printAll(n.ModuleSpecifier, ";")
+}
+
+func (p *printer) printImportClause(n *ast.ImportClause) error {
+	if n.IsTypeOnly {
+		p.write("type ")
+	}
+	if n.Name != nil {
+		if err := p.print(n.Name); err != nil {
+			return err
+		}
+		if n.NamedBindings != nil {
+			p.write(", ")
+		}
+	}
+	if n.NamedBindings != nil {
+		return p.print(n.NamedBindings)
+	}
+	return nil
+}
+
+func (p *printer) printExportDeclaration(n *ast.ExportDeclaration) error {
+	p.leadingComment(n.LeadingComment)
+	p.write("export ")
+	if n.IsTypeOnly {
+		p.write("type ")
+	}
+	if n.ExportClause != nil {
+		if err := p.print(n.ExportClause); err != nil {
+			return err
+		}
+	} else {
+		p.write("*")
+	}
+	if n.ModuleSpecifier != nil {
+		if err := p.printAll(" from ", n.ModuleSpecifier); err != nil {
+			return err
+		}
+	}
+	p.write(";")
+	return nil
+}
+
+func printNamedBindings[T ast.Node](p *printer, elements []T) error {
+	if len(elements) == 0 {
+		p.write("{}")
+		return nil
+	}
+	p.write("{ ")
+	if err := printList(p, elements, ", "); err != nil {
+		return err
+	}
+	p.write(" }")
+	return nil
+}
+
+func (p *printer) printSpecifier(isTypeOnly bool, propertyName, name *ast.Identifier) error {
+	if isTypeOnly {
+		p.write("type ")
+	}
+	if propertyName != nil {
+		if err := p.printAll(propertyName, " as "); err != nil {
+			return err
+		}
+	}
+	return p.print(name)
+}
+
+func (p *printer) printFunctionDeclaration(n *ast.FunctionDeclaration) error {
+	p.leadingComment(n.LeadingComment)
+	p.modifiers(n.Modifiers)
+	p.write("function")
+	if n.Name != nil {
+		if err := p.printAll(" ", n.Name); err != nil {
+			return err
+		}
+	}
+	if err := p.printSignature(n.TypeParameters, n.Parameters); err != nil {
+		return err
+	}
+	if n.Type != nil {
+		if err := p.printAll(": ", n.Type); err != nil {
+			return err
+		}
+	}
+	p.write(";")
+	return nil
+}
+
+// printSignature prints the type parameters and parameters of a function.
+func (p *printer) printSignature(typeParams []*ast.TypeParameter, params []*ast.Parameter) error {
+	if err := p.printTypeParameters(typeParams); err != nil {
+		return err
+	}
+	p.write("(")
+	if err := printList(p, params, ", "); err != nil {
+		return err
+	}
+	p.write(")")
+	return nil
+}
+
+func (p *printer) printParameter(n *ast.Parameter) error {
+	p.modifiers(n.Modifiers)
+	if n.DotDotDotToken {
+		p.write("...")
+	}
+	if err := p.print(n.Name); err != nil {
+		return err
+	}
+	if n.QuestionToken {
+		p.write("?")
+	}
+	if n.Type != nil {
+		if err := p.printAll(": ", n.Type); err != nil {
+			return err
+		}
+	}
+	if n.Initializer != nil {
+		return p.printAll(" = ", n.Initializer)
+	}
+	return nil
+}
+
+func (p *printer) printEnumDeclaration(n *ast.EnumDeclaration) error {
+	p.leadingComment(n.LeadingComment)
+	p.modifiers(n.Modifiers)
+	if err := p.printAll("enum ", n.Name, " {"); err != nil {
+		return err
+	}
+	if len(n.Members) > 0 {
+		p.indent++
+		for _, member := range n.Members {
+			p.linebreak()
+			if err := p.printAll(member, ","); err != nil {
+				return err
+			}
+		}
+		p.indent--
+		p.linebreak()
+	}
+	p.write("}")
+	return nil
+}
+
+func (p *printer) printEnumMember(n *ast.EnumMember) error {
+	p.leadingComment(n.LeadingComment)
+	if err := p.print(n.Name); err != nil {
+		return err
+	}
+	if n.Initializer != nil {
+		return p.printAll(" = ", n.Initializer)
+	}
+	return nil
+}
+
+func (p *printer) printInterfaceDeclaration(n *ast.InterfaceDeclaration) error {
+	p.leadingComment(n.LeadingComment)
+	p.modifiers(n.Modifiers)
+	if err := p.printAll("interface ", n.Name); err != nil {
+		return err
+	}
+	if err := p.printTypeParameters(n.TypeParameters); err != nil {
+		return err
+	}
+	if err := p.printHeritageClauses(n.HeritageClauses); err != nil {
+		return err
+	}
+	p.write(" ")
+	return printMembers(p, n.Members, true)
+}
+
+func (p *printer) printClassDeclaration(n *ast.ClassDeclaration) error {
+	p.leadingComment(n.LeadingComment)
+	p.modifiers(n.Modifiers)
+	p.write("class")
+	if n.Name != nil {
+		if err := p.printAll(" ", n.Name); err != nil {
+			return err
+		}
+	}
+	if err := p.printTypeParameters(n.TypeParameters); err != nil {
+		return err
+	}
+	if err := p.printHeritageClauses(n.HeritageClauses); err != nil {
+		return err
+	}
+	p.write(" ")
+	return printMembers(p, n.Members, true)
+}
+
+// printHeritageClauses prints the extends and implements clauses of an
+// interface or class. Consecutive clauses with the same keyword are joined
+// into a single comma-separated list.
+func (p *printer) printHeritageClauses(clauses []*ast.HeritageClause) error {
+	for i, clause := range clauses {
+		if i > 0 && heritageKeyword(clause) == heritageKeyword(clauses[i-1]) {
+			p.write(", ")
+			if err := printList(p, clause.Types, ", "); err != nil {
+				return err
+			}
+			continue
+		}
+		p.write(" ")
+		if err := p.print(clause); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func heritageKeyword(n *ast.HeritageClause) string {
+	if n.Token == token.Implements {
+		return "implements"
+	}
+	return "extends"
+}
+
+func (p *printer) printTypeParameters(params []*ast.TypeParameter) error {
+	if len(params) == 0 {
+		return nil
+	}
+	p.write("<")
+	if err := printList(p, params, ", "); err != nil {
+		return err
+	}
+	p.write(">")
+	return nil
+}
+
+func (p *printer) printTypeParameter(n *ast.TypeParameter) error {
+	p.modifiers(n.Modifiers)
+	if err := p.print(n.Name); err != nil {
+		return err
+	}
+	if n.Constraint != nil {
+		if err := p.printAll(" extends ", n.Constraint); err != nil {
+			return err
+		}
+	}
+	if n.Default != nil {
+		return p.printAll(" = ", n.Default)
+	}
+	return nil
+}
+
+func (p *printer) printTypeArguments(args []ast.Type) error {
+	if len(args) == 0 {
+		return nil
+	}
+	p.write("<")
+	if err := printList(p, args, ", "); err != nil {
+		return err
+	}
+	p.write(">")
+	return nil
+}
+
+func (p *printer) printTypeLiteral(n *ast.TypeLiteral) error {
+	multiline := false
+	for _, member := range n.Members {
+		if hasComment(member) {
+			multiline = true
+		}
+	}
+	return printMembers(p, n.Members, multiline)
+}
+
+// printMembers prints the members of an interface, type literal or class,
+// either one per line or all on a single line.
+func printMembers[T ast.Node](p *printer, members []T, multiline bool) error {
+	if len(members) == 0 {
+		p.write("{}")
+		return nil
+	}
+
+	if !multiline {
+		p.write("{ ")
+		for i, member := range members {
+			if i > 0 {
+				p.write(" ")
+			}
+			if err := p.print(member); err != nil {
+				return err
+			}
+		}
+		p.write(" }")
+		return nil
+	}
+
+	p.write("{")
+	p.indent++
+	for i, member := range members {
+		if i > 0 && hasLeadingComment(member) {
+			p.blankLine()
+		} else {
+			p.linebreak()
+		}
+		if err := p.print(member); err != nil {
+			return err
+		}
+	}
+	p.indent--
+	p.linebreak()
+	p.write("}")
+	return nil
+}
+
+func (p *printer) printPropertySignature(n *ast.PropertySignature) error {
+	p.leadingComment(n.LeadingComment)
+	p.modifiers(n.Modifiers)
+	if err := p.print(n.Name); err != nil {
+		return err
+	}
+	if n.QuestionToken {
+		p.write("?")
+	}
+	if err := p.printAll(": ", n.Type, ";"); err != nil {
+		return err
+	}
+	p.trailingComment(n.TrailingComment)
+	return nil
+}
+
+func (p *printer) printPropertyDeclaration(n *ast.PropertyDeclaration) error {
+	p.leadingComment(n.LeadingComment)
+	p.modifiers(n.Modifiers)
+	if err := p.print(n.Name); err != nil {
+		return err
+	}
+	if n.QuestionToken {
+		p.write("?")
+	}
+	if n.Type != nil {
+		if err := p.printAll(": ", n.Type); err != nil {
+			return err
+		}
+	}
+	if n.Initializer != nil {
+		if err := p.printAll(" = ", n.Initializer); err != nil {
+			return err
+		}
+	}
+	p.write(";")
+	p.trailingComment(n.TrailingComment)
+	return nil
+}
+
+func (p *printer) printMethodDeclaration(n *ast.MethodDeclaration) error {
+	p.leadingComment(n.LeadingComment)
+	p.modifiers(n.Modifiers)
+	if err := p.print(n.Name); err != nil {
+		return err
+	}
+	if n.QuestionToken {
+		p.write("?")
+	}
+	return p.printCallLikeSignature("", n.TypeParameters, n.Parameters, n.Type, n.TrailingComment)
+}
+
+func (p *printer) printMethodSignature(n *ast.MethodSignature) error {
+	p.leadingComment(n.LeadingComment)
+	if err := p.print(n.Name); err != nil {
+		return err
+	}
+	if n.QuestionToken {
+		p.write("?")
+	}
+	return p.printCallLikeSignature("", n.TypeParameters, n.Parameters, n.Type, n.TrailingComment)
+}
+
+// printCallLikeSignature prints the remainder of a method, call or construct
+// signature, starting from the given prefix.
+func (p *printer) printCallLikeSignature(prefix string, typeParams []*ast.TypeParameter, params []*ast.Parameter, typ ast.Type, trailingComment string) error {
+	p.write(prefix)
+	if err := p.printSignature(typeParams, params); err != nil {
+		return err
+	}
+	if typ != nil {
+		if err := p.printAll(": ", typ); err != nil {
+			return err
+		}
+	}
+	p.write(";")
+	p.trailingComment(trailingComment)
+	return nil
+}
+
+func (p *printer) printIndexSignature(n *ast.IndexSignature) error {
+	p.leadingComment(n.LeadingComment)
+	p.modifiers(n.Modifiers)
+	p.write("[")
+	if err := printList(p, n.Parameters, ", "); err != nil {
+		return err
+	}
+	return p.printAll("]: ", n.Type, ";")
+}
+
+func (p *printer) printArrayType(n *ast.ArrayType) error {
+	if err := p.printType(n.ElementType, precPostfix); err != nil {
+		return err
+	}
+	p.write("[]")
+	return nil
+}
+
+func (p *printer) printUnionType(n *ast.UnionType) error {
+	if len(n.Types) == 1 {
+		p.write("| ")
+	}
+	for i, typ := range n.Types {
+		if i > 0 {
+			p.write(" | ")
+		}
+		if err := p.printType(typ, precIntersection); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) printIntersectionType(n *ast.IntersectionType) error {
+	if len(n.Types) == 1 {
+		p.write("& ")
+	}
+	for i, typ := range n.Types {
+		if i > 0 {
+			p.write(" & ")
+		}
+		if err := p.printType(typ, precOperator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) printConditionalType(n *ast.ConditionalType) error {
+	if err := p.printType(n.CheckType, precUnion); err != nil {
+		return err
+	}
+	p.write(" extends ")
+	// A function type may appear unparenthesized in the extends clause, but a
+	// nested conditional type may not.
+	var err error
+	if _, ok := n.ExtendsType.(*ast.ConditionalType); ok {
+		err = p.printAll("(", n.ExtendsType, ")")
+	} else {
+		err = p.print(n.ExtendsType)
+	}
+	if err != nil {
+		return err
+	}
+	return p.printAll(" ? ", n.TrueType, " : ", n.FalseType)
+}
+
+func (p *printer) printMappedType(n *ast.MappedType) error {
+	p.write("{ ")
+	switch n.ReadonlyToken {
+	case token.Readonly:
+		p.write("readonly ")
+	case token.Plus, token.Minus:
+		p.write(n.ReadonlyToken.String(), "readonly ")
+	default:
+	}
+	if err := p.printAll("[", n.TypeParameter.Name, " in ", n.TypeParameter.Constraint); err != nil {
+		return err
+	}
+	if n.NameType != nil {
+		if err := p.printAll(" as ", n.NameType); err != nil {
+			return err
+		}
+	}
+	p.write("]")
+	switch n.QuestionToken {
+	case token.Question:
+		p.write("?")
+	case token.Plus, token.Minus:
+		p.write(n.QuestionToken.String(), "?")
+	default:
+	}
+	if n.Type != nil {
+		if err := p.printAll(": ", n.Type); err != nil {
+			return err
+		}
+	}
+	p.write("; }")
+	return nil
+}
+
+func (p *printer) printInferType(n *ast.InferType) error {
+	if err := p.printAll("infer ", n.TypeParameter.Name); err != nil {
+		return err
+	}
+	if n.TypeParameter.Constraint != nil {
+		return p.printAll(" extends ", n.TypeParameter.Constraint)
+	}
+	return nil
+}
+
+// Type precedence levels, from the loosest to the tightest binding. They
+// determine where parentheses are required around nested types.
+const (
+	precConditional  = iota // conditional and function types
+	precUnion               // A | B
+	precIntersection        // A & B
+	precOperator            // infer T, keyof T
+	precPostfix             // T[], T[K]
+	precPrimary             // all other types
+)
+
+func typePrecedence(typ ast.Expr) int {
+	switch typ.(type) {
+	case *ast.ConditionalType, *ast.FunctionType:
+		return precConditional
+	case *ast.UnionType:
+		return precUnion
+	case *ast.IntersectionType:
+		return precIntersection
+	case *ast.InferType, *ast.TypeOperator:
+		return precOperator
+	case *ast.ArrayType, *ast.IndexedAccessType:
+		return precPostfix
+	default:
+		return precPrimary
+	}
+}
+
+// printType prints a type, wrapping it in parentheses if it binds more
+// loosely than the given precedence.
+func (p *printer) printType(typ ast.Expr, prec int) error {
+	if typePrecedence(typ) < prec {
+		return p.printAll("(", typ, ")")
+	}
+	return p.print(typ)
+}
+
+func (p *printer) printFunctionType(n *ast.FunctionType) error {
+	if err := p.printSignature(n.TypeParameters, n.Parameters); err != nil {
+		return err
+	}
+	return p.printAll(" => ", n.Type)
+}
+
+// leadingComment prints a comment as a JSDoc comment on the lines preceding a
+// node.
+func (p *printer) leadingComment(text string) {
+	if text == "" {
+		return
+	}
+	p.write("/**")
+	for _, line := range strings.Split(escapeBlockComment(text), "\n") {
+		p.linebreak()
+		p.write(" *")
+		if line != "" {
+			p.write(" ", line)
+		}
+	}
+	p.linebreak()
+	p.write(" */")
+	p.linebreak()
+}
+
+// modifiers prints a set of modifier keywords followed by a space.
+func (p *printer) modifiers(m ast.ModifierFlags) {
+	if m != 0 {
+		p.write(m.String(), " ")
+	}
+}
+
+// trailingComment prints a comment following a node, as a line comment
+// unless it spans several lines.
+func (p *printer) trailingComment(text string) {
+	if text == "" {
+		return
+	}
+	if !strings.Contains(text, "\n") {
+		p.write(" // ", text)
+		return
+	}
+	for i, line := range strings.Split(escapeBlockComment(text), "\n") {
+		switch {
+		case i == 0:
+			p.write(" /* ", line)
+		case line == "":
+			p.linebreak()
+			p.write(" *")
+		default:
+			p.linebreak()
+			p.write(" * ", line)
+		}
+	}
+	p.write(" */")
+}
+
+// escapeBlockComment escapes any */ in text, which would otherwise end the
+// block comment containing it.
+func escapeBlockComment(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
+func (p *printer) write(strs ...string) {
+	for _, s := range strs {
+		p.buf.WriteString(s)
+	}
+}
+
+func (p *printer) linebreak() {
+	p.buf.WriteByte('\n')
+	for i := 0; i < p.indent; i++ {
+		p.buf.WriteString(p.indentString)
+	}
+}
+
+func (p *printer) blankLine() {
+	p.buf.WriteByte('\n')
+	p.linebreak()
+}
+
+func hasLeadingComment(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.PropertySignature:
+		return n.LeadingComment != ""
+	case *ast.IndexSignature:
+		return n.LeadingComment != ""
+	case *ast.MethodSignature:
+		return n.LeadingComment != ""
+	case *ast.CallSignature:
+		return n.LeadingComment != ""
+	case *ast.ConstructSignature:
+		return n.LeadingComment != ""
+	case *ast.PropertyDeclaration:
+		return n.LeadingComment != ""
+	case *ast.MethodDeclaration:
+		return n.LeadingComment != ""
+	case *ast.Constructor:
+		return n.LeadingComment != ""
+	case *ast.GetAccessor:
+		return n.LeadingComment != ""
+	case *ast.SetAccessor:
+		return n.LeadingComment != ""
+	default:
+		return false
+	}
+}
+
+func hasComment(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.PropertySignature:
+		return n.LeadingComment != "" || n.TrailingComment != ""
+	case *ast.MethodSignature:
+		return n.LeadingComment != "" || n.TrailingComment != ""
+	case *ast.CallSignature:
+		return n.LeadingComment != "" || n.TrailingComment != ""
+	case *ast.ConstructSignature:
+		return n.LeadingComment != "" || n.TrailingComment != ""
+	default:
+		return hasLeadingComment(node)
+	}
+}
@@ -0,0 +1,35 @@
+package printer_test
+
+import (
+	"os"
+
+	"github.com/armsnyder/typescript-ast-go/ast"
+	"github.com/armsnyder/typescript-ast-go/parser"
+	"github.com/armsnyder/typescript-ast-go/printer"
+)
+
+func Example() {
+	sourceFile := parser.Parse([]byte(`
+		interface Position { line: uinteger; character: uinteger; }`))
+
+	decl := sourceFile.Statements[0].(*ast.InterfaceDeclaration)
+	decl.Members = append(decl.Members, &ast.PropertySignature{
+		Name:            &ast.Identifier{Text: "offset"},
+		QuestionToken:   true,
+		Type:            &ast.TypeReference{TypeName: &ast.Identifier{Text: "uinteger"}},
+		LeadingComment:  "The byte offset.",
+		TrailingComment: "Not part of the spec",
+	})
+
+	_ = printer.Fprint(os.Stdout, sourceFile)
+	// Output:
+	// interface Position {
+	// 	line: uinteger;
+	// 	character: uinteger;
+	//
+	// 	/**
+	// 	 * The byte offset.
+	// 	 */
+	// 	offset?: uinteger; // Not part of the spec
+	// }
+}
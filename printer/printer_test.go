@@ -0,0 +1,419 @@
+package printer_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/armsnyder/typescript-ast-go/ast"
+	"github.com/armsnyder/typescript-ast-go/internal/asttest"
+	"github.com/armsnyder/typescript-ast-go/parser"
+	"github.com/armsnyder/typescript-ast-go/printer"
+	"github.com/armsnyder/typescript-ast-go/token"
+)
+
+func TestFprint(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "interface",
+			src: `/** A generic interface. */ interface Foo<T, U> extends Bar, Baz {
+  /**
+   * The first property.
+   *
+   * @since 3.17.0
+   */
+  a?: string | number;
+  b: T[]; // Trailing comment
+  /** Index signature. */
+  [key: string]: (A | B)[];
+}`,
+			want: `/**
+ * A generic interface.
+ */
+interface Foo<T, U> extends Bar, Baz {
+	/**
+	 * The first property.
+	 *
+	 * @since 3.17.0
+	 */
+	a?: string | number;
+	b: T[]; // Trailing comment
+
+	/**
+	 * Index signature.
+	 */
+	[key: string]: (A | B)[];
+}
+`,
+		},
+		{
+			name: "enum",
+			src: `export enum Kind { A = 'a',
+  /** Second. */ B = -1, C }`,
+			want: `export enum Kind {
+	A = 'a',
+	/**
+	 * Second.
+	 */
+	B = -1,
+	C,
+}
+`,
+		},
+		{
+			name: "namespace",
+			src: `export namespace Codes {
+  export const A: integer = -32700;
+  export const B: 1 = 1, C = [1, 'a'];
+}
+export type Codes = 1 | 2;`,
+			want: `export namespace Codes {
+	export const A: integer = -32700;
+
+	export const B: 1 = 1, C = [1, 'a'];
+}
+
+export type Codes = 1 | 2;
+`,
+		},
+		{
+			name: "function",
+			src: `/** Overload. */
+export function f<T>(a: string, b?: number, ...rest: T[]): void;
+function f(handler: (err: Error | null, ...args: any[]) => void, c = 1);
+type Handlers = (() => void)[] | ((a) => void);`,
+			want: `/**
+ * Overload.
+ */
+export function f<T>(a: string, b?: number, ...rest: T[]): void;
+
+function f(handler: (err: Error | null, ...args: any[]) => void, c = 1);
+
+type Handlers = (() => void)[] | ((a) => void);
+`,
+		},
+		{
+			name: "signatures",
+			src: `interface Foo {
+  /** Gets a value. */ get(key: string): Value; // Trailing
+  map?<T>(f: (v: Value) => T): T[];
+  (x: number): string;
+  new <T>(s: T): Foo;
+}
+type Callable = { (): void; new (); };`,
+			want: `interface Foo {
+	/**
+	 * Gets a value.
+	 */
+	get(key: string): Value; // Trailing
+	map?<T>(f: (v: Value) => T): T[];
+	(x: number): string;
+	new <T>(s: T): Foo;
+}
+
+type Callable = { (): void; new (); };
+`,
+		},
+		{
+			name: "class",
+			src: `declare abstract class Foo<T> extends Bar implements Baz, Qux {
+  static readonly count: number; private name?: string = 'w';
+  readonly [key: string]: T;
+  constructor(public readonly id: string);
+  /** Draws. */ protected abstract draw<U>(ctx: U): void;
+  get size(): number; set size(value: number);
+}`,
+			want: `declare abstract class Foo<T> extends Bar implements Baz, Qux {
+	static readonly count: number;
+	private name?: string = 'w';
+	readonly [key: string]: T;
+	constructor(public readonly id: string);
+
+	/**
+	 * Draws.
+	 */
+	protected abstract draw<U>(ctx: U): void;
+	get size(): number;
+	set size(value: number);
+}
+`,
+		},
+		{
+			name: "imports and exports",
+			src: `import 'polyfill';
+import Default from './default';
+import type { A, B as C } from './types';
+import D, * as ns from './ns';
+import { type E, type } from './mixed';
+export { A, C as F };
+export type { G } from './g';
+export * from './all';
+export * as all from './all';
+export default Default;
+export = ns;
+export default abstract class {}
+export declare function f(): void;`,
+			want: `import 'polyfill';
+
+import Default from './default';
+
+import type { A, B as C } from './types';
+
+import D, * as ns from './ns';
+
+import { type E, type } from './mixed';
+
+export { A, C as F };
+
+export type { G } from './g';
+
+export * from './all';
+
+export * as all from './all';
+
+export default Default;
+
+export = ns;
+
+export default abstract class {}
+
+export declare function f(): void;
+`,
+		},
+		{
+			name: "type alias",
+			src:  `type A = { a: [B.C, 'd']; b: { [key: string]: E }; c: {} } | (F);`,
+			want: "type A = { a: [B.C, 'd']; b: { [key: string]: E; }; c: {}; } | (F);\n",
+		},
+		{
+			name: "intersection types",
+			src: `type A = B & (C | D) & (() => void) | E;
+type F = (G & H)[] | I;
+type J = | K;`,
+			want: `type A = B & (C | D) & (() => void) | E;
+
+type F = (G & H)[] | I;
+
+type J = | K;
+`,
+		},
+		{
+			name: "conditional types",
+			src: `type A<T> = T extends (...args: any[]) => infer R ? R : never;
+type B<T> = T extends [infer U extends string] ? U : (T extends C ? D : E)[];
+type F<T> = T extends [infer H, ...infer R] ? H : never;`,
+			want: `type A<T> = T extends (...args: any[]) => infer R ? R : never;
+
+type B<T> = T extends [infer U extends string] ? U : (T extends C ? D : E)[];
+
+type F<T> = T extends [infer H, ...infer R] ? H : never;
+`,
+		},
+		{
+			name: "mapped types",
+			src: `type A = { readonly [K in Keys]?: V };
+type B = { -readonly [P in K as Foo<P>]-?: X; };
+type C = { +readonly [P in K]+? };`,
+			want: `type A = { readonly [K in Keys]?: V; };
+
+type B = { -readonly [P in K as Foo<P>]-?: X; };
+
+type C = { +readonly [P in K]+?; };
+`,
+		},
+		{
+			name: "type operators",
+			src:  `type A = keyof typeof a.b<T> | unique symbol | readonly string[] | (keyof T)[];`,
+			want: "type A = keyof typeof a.b<T> | unique symbol | readonly string[] | (keyof T)[];\n",
+		},
+		{
+			name: "indexed access types",
+			src:  `type A = T[][] | Params['textDocument'][number] | (keyof T)[K];`,
+			want: "type A = T[][] | Params['textDocument'][number] | (keyof T)[K];\n",
+		},
+		{
+			name: "template literals",
+			src:  "type A = `on${Capitalize<N>}` | `${A}-${B}` | `x`;\nconst c = `a${1}b`;",
+			want: "type A = `on${Capitalize<N>}` | `${A}-${B}` | `x`;\n\nconst c = `a${1}b`;\n",
+		},
+		{
+			name: "string literals",
+			src:  `type A = "it's" | 'say \'hi\'' | "\u{1F600}";`,
+			want: "type A = \"it's\" | 'say \\'hi\\'' | \"\\u{1F600}\";\n",
+		},
+		{
+			name: "numeric literals",
+			src:  `type A = 0x1F | 1_000.5e-3 | 10n | -1 | -2n;`,
+			want: "type A = 0x1F | 1_000.5e-3 | 10n | -1 | -2n;\n",
+		},
+		{
+			name: "regular expressions",
+			src:  `export const pattern = /^[a-z\/]+$/giu;`,
+			want: "export const pattern = /^[a-z\\/]+$/giu;\n",
+		},
+		{
+			name: "type parameters",
+			src:  `type Pair<const K extends string, in out V = K> = [K, V];`,
+			want: "type Pair<const K extends string, in out V = K> = [K, V];\n",
+		},
+		{
+			name: "type arguments",
+			src:  `interface A<T> extends Base<T> { b: Map<string, Array<T>>; c: Promise<(A | B)[]>; }`,
+			want: `interface A<T> extends Base<T> {
+	b: Map<string, Array<T>>;
+	c: Promise<(A | B)[]>;
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, parser.Parse([]byte(tt.src))); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFprint_Node(t *testing.T) {
+	node := &ast.ArrayType{
+		ElementType: &ast.UnionType{
+			Types: []ast.Type{
+				&ast.TypeReference{TypeName: &ast.Identifier{Text: "A"}},
+				&ast.LiteralType{Literal: &ast.PrefixUnaryExpression{
+					Operator: token.Minus,
+					Operand:  &ast.NumericLiteral{Text: "1"},
+				}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&printer.Config{Indent: "  "}).Fprint(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "(A | -1)[]"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFprint_Comments(t *testing.T) {
+	node := &ast.SourceFile{
+		Statements: []ast.Stmt{
+			&ast.InterfaceDeclaration{
+				Name: &ast.Identifier{Text: "A"},
+				Members: []ast.Signature{
+					&ast.PropertySignature{
+						Name:            &ast.Identifier{Text: "a"},
+						Type:            &ast.TypeReference{TypeName: &ast.Identifier{Text: "string"}},
+						LeadingComment:  "Matches **/*.ts files.",
+						TrailingComment: "first line\n\nlast */ line",
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, node); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `interface A {
+	/**
+	 * Matches **\/*.ts files.
+	 */
+	a: string; /* first line
+	 *
+	 * last *\/ line */
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := parser.ParseFile(buf.Bytes(), 0); err != nil {
+		t.Errorf("printed source does not parse: %v", err)
+	}
+}
+
+func TestFprint_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{
+			name:    "bad type",
+			src:     "type A = *;",
+			wantErr: "printer: cannot print *ast.BadType in place of invalid source",
+		},
+		{
+			name:    "bad statement",
+			src:     "let a: string;",
+			wantErr: "printer: cannot print *ast.BadStmt in place of invalid source",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourceFile, _ := parser.ParseFile([]byte(tt.src), parser.RecoverErrors)
+
+			err := printer.Fprint(io.Discard, sourceFile)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFprint_RoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../internal/testdata/*.ts.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".ts.txt"), func(t *testing.T) {
+			source, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := parser.Parse(source)
+
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, want); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parser.ParseFile(buf.Bytes(), 0)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, buf.String())
+			}
+
+			// The printer prints only the leading and trailing comments of
+			// nodes, not the source file's list of comments.
+			got.Comments, want.Comments = nil, nil
+
+			asttest.ClearPositions(want)
+			asttest.ClearPositions(got)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("printed source does not parse to the same tree:\n%s", buf.String())
+			}
+		})
+	}
+}
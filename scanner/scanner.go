@@ -0,0 +1,927 @@
+// Package scanner implements a scanner for TypeScript source text. It takes
+// a []byte as source which can then be tokenized through repeated calls to
+// the Scan method.
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/armsnyder/typescript-ast-go/token"
+)
+
+// An ErrorHandler may be provided to [Scanner.Init]. If a syntax error is
+// encountered and a handler was installed, the handler is called with a
+// position and an error message. The position points to the beginning of
+// the offending token.
+type ErrorHandler func(pos token.Pos, msg string)
+
+// A Mode value is a set of flags (or 0). They control scanner behavior.
+type Mode uint
+
+const (
+	ScanComments   Mode = 1 << iota // return comments as Comment and LineComment tokens
+	ScanWhitespace                  // return spaces, tabs and line breaks as Whitespace tokens
+)
+
+// A Scanner holds the scanner's internal state while processing a given
+// source. It can be allocated as part of another data structure but must be
+// initialized via [Scanner.Init] before use.
+//
+// A Scanner holds no references other than to its source, so a copy of a
+// Scanner may be used to look ahead without disturbing the original.
+type Scanner struct {
+	// immutable state
+	src  []byte
+	err  ErrorHandler
+	mode Mode
+
+	// scanning state
+	offset                int
+	start                 int
+	lines                 []int
+	willBeTrailingComment bool
+
+	// prev is the kind of the last token other than whitespace or a comment,
+	// which determines whether a / begins a regular expression literal.
+	prev token.Kind
+
+	// braceDepth is the number of unclosed braces since the start of the
+	// innermost template substitution. templateBraceDepths holds the
+	// braceDepth of each enclosing template substitution.
+	braceDepth          int
+	templateBraceDepths []int
+
+	// public state - ok to modify
+	ErrorCount int // number of errors encountered
+}
+
+// Init prepares the scanner s to tokenize src by setting the scanner at the
+// beginning of src.
+//
+// Calls to [Scanner.Scan] will invoke the error handler err if they
+// encounter a syntax error and err is not nil. Also, for each error
+// encountered, the Scanner field ErrorCount is incremented by one. The mode
+// parameter determines how comments and whitespace are handled.
+//
+// Positions treat \n, \r\n, \r, U+2028 and U+2029 as line breaks, which are
+// the ECMAScript line terminators.
+func (s *Scanner) Init(src []byte, err ErrorHandler, mode Mode) {
+	s.src = src
+	s.err = err
+	s.mode = mode
+
+	s.offset = 0
+	s.start = 0
+	s.lines = []int{0}
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRune(src[i:])
+		i += size
+		// A \r\n sequence is a single line break.
+		if isLineTerminator(r) && !(r == '\r' && i < len(src) && src[i] == '\n') {
+			s.lines = append(s.lines, i)
+		}
+	}
+	s.willBeTrailingComment = false
+	s.prev = token.Illegal
+	s.braceDepth = 0
+	s.templateBraceDepths = nil
+	s.ErrorCount = 0
+}
+
+// Scan scans the next token and returns the token position, the token kind,
+// and its literal string if applicable. The source end is indicated by
+// [token.EOF].
+//
+// If the returned token is an identifier, keyword or literal, the literal
+// string has the corresponding value:
+//
+//   - String and template literals exclude their delimiters, and escape
+//     sequences are left undecoded. Use [DecodeString] to decode a String.
+//   - Regex literals include their slashes and flags.
+//   - Identifiers containing unicode escape sequences are decoded.
+//   - Comments exclude the comment markers, surrounding whitespace and the
+//     leading asterisks of each line of a block comment.
+//   - Whitespace is the raw source text.
+//
+// For all other tokens, including Illegal, lit is the empty string.
+//
+// A comment is a Comment token if it begins a line, or a LineComment token
+// if it follows another token on the same line. Comments and whitespace are
+// skipped unless the corresponding [Mode] flag was passed to
+// [Scanner.Init].
+func (s *Scanner) Scan() (pos token.Pos, kind token.Kind, lit string) {
+	for {
+		tok := s.scan()
+		switch tok.Kind {
+		case token.Comment, token.LineComment:
+			if s.mode&ScanComments == 0 {
+				continue
+			}
+		case token.Whitespace:
+			if s.mode&ScanWhitespace == 0 {
+				continue
+			}
+		default:
+			s.prev = tok.Kind
+		}
+		return s.pos(s.start), tok.Kind, tok.Text
+	}
+}
+
+// End returns the position immediately after the token most recently
+// returned by [Scanner.Scan].
+func (s *Scanner) End() token.Pos {
+	return s.pos(s.offset)
+}
+
+// pos returns the position of the given byte offset in the source.
+func (s *Scanner) pos(offset int) token.Pos {
+	line := sort.SearchInts(s.lines, offset+1)
+	return token.Pos{Offset: offset, Line: line, Column: offset - s.lines[line-1] + 1}
+}
+
+// punctuators maps the first character of each punctuator that needs no
+// special handling to the kinds of punctuator it may begin, longest first.
+var punctuators = map[byte][]token.Kind{
+	'|': {token.LogicalOrAssign, token.LogicalOr, token.OrAssign, token.Or},
+	'&': {token.LogicalAndAssign, token.LogicalAnd, token.AndAssign, token.And},
+	'^': {token.XorAssign, token.Xor},
+	'=': {token.StrictEq, token.Eq, token.Arrow, token.Assign},
+	'!': {token.StrictNotEq, token.NotEq, token.Not},
+	'-': {token.Dec, token.MinusAssign, token.Minus},
+	'+': {token.Inc, token.PlusAssign, token.Plus},
+	'*': {token.StarStarAssign, token.StarStar, token.StarAssign, token.Star},
+	'%': {token.PercentAssign, token.Percent},
+	'<': {token.ShlAssign, token.Shl, token.LessEq, token.LAngle},
+	'>': {token.UShrAssign, token.UShr, token.ShrAssign, token.Shr, token.GreaterEq, token.RAngle},
+	'~': {token.Tilde},
+	'(': {token.LParen},
+	')': {token.RParen},
+	'[': {token.LBrack},
+	']': {token.RBrack},
+	',': {token.Comma},
+	':': {token.Colon},
+	';': {token.Semicolon},
+	'@': {token.At},
+	'#': {token.Hash},
+}
+
+func (s *Scanner) scan() token.Token {
+	s.start = s.offset
+	if s.offset >= len(s.src) {
+		return token.Token{Kind: token.EOF}
+	}
+
+	switch s.src[s.offset] {
+	case ' ', '\t', '\v', '\f', '\r', '\n':
+		return s.nextWhitespace()
+
+	case '/':
+		return s.nextComment()
+
+	default:
+		if r, _ := utf8.DecodeRune(s.src[s.offset:]); isWhitespace(r) || isLineTerminator(r) {
+			return s.nextWhitespace()
+		}
+	}
+
+	s.willBeTrailingComment = true
+
+	switch s.src[s.offset] {
+	case '{':
+		s.braceDepth++
+		return s.char(token.LBrace)
+
+	case '}':
+		if s.braceDepth == 0 && len(s.templateBraceDepths) > 0 {
+			return s.nextTemplateContinuation()
+		}
+		// A stray closing brace is left for the parser to report, and
+		// must not unbalance the depth of any later template.
+		if s.braceDepth > 0 {
+			s.braceDepth--
+		}
+		return s.char(token.RBrace)
+
+	case '`':
+		return s.nextTemplate()
+
+	case '.':
+		if s.offset+1 < len(s.src) && isDecimalDigit(s.src[s.offset+1]) {
+			return s.nextNumber()
+		}
+		return s.longest(token.Ellipsis, token.Dot)
+
+	case '?':
+		// In a?.5:b, the ? begins a conditional expression.
+		if s.hasPrefix("?.") && s.offset+2 < len(s.src) && isDecimalDigit(s.src[s.offset+2]) {
+			return s.char(token.Question)
+		}
+		return s.longest(token.NullishAssign, token.Nullish, token.QuestionDot, token.Question)
+
+	case '\'', '"':
+		return s.nextString()
+
+	default:
+		if kinds, ok := punctuators[s.src[s.offset]]; ok {
+			return s.longest(kinds...)
+		}
+
+		if s.src[s.offset] >= '0' && s.src[s.offset] <= '9' {
+			return s.nextNumber()
+		}
+
+		r, size := utf8.DecodeRune(s.src[s.offset:])
+		if isIdentifierStart(r) || r == '\\' {
+			return s.nextIdent()
+		}
+
+		return s.illegal(s.offset+size, fmt.Sprintf("illegal character %#U", r))
+	}
+}
+
+// nextWhitespace scans a run of whitespace and line terminators.
+func (s *Scanner) nextWhitespace() token.Token {
+	for s.offset < len(s.src) {
+		r, size := utf8.DecodeRune(s.src[s.offset:])
+		switch {
+		case isLineTerminator(r):
+			s.willBeTrailingComment = false
+		case isWhitespace(r):
+		default:
+			return s.whitespace()
+		}
+		s.offset += size
+	}
+	return s.whitespace()
+}
+
+// whitespace returns a Whitespace token for the text since the token start.
+// The text is omitted when whitespace is not being returned to the caller.
+func (s *Scanner) whitespace() token.Token {
+	if s.mode&ScanWhitespace == 0 {
+		return token.Token{Kind: token.Whitespace}
+	}
+	return token.Token{Kind: token.Whitespace, Text: string(s.src[s.start:s.offset])}
+}
+
+func (s *Scanner) nextComment() token.Token {
+	switch {
+	case s.hasPrefix("//"):
+		return s.nextLineComment()
+
+	case s.hasPrefix("/*"):
+		return s.nextBlockComment()
+
+	case s.isRegexAllowed():
+		s.willBeTrailingComment = true
+		return s.nextRegex()
+
+	default:
+		s.willBeTrailingComment = true
+		return s.longest(token.SlashAssign, token.Slash)
+	}
+}
+
+// isRegexAllowed reports whether a / begins a regular expression literal
+// rather than a division operator. Division follows tokens that may end an
+// expression, such as identifiers, literals and closing brackets.
+func (s *Scanner) isRegexAllowed() bool {
+	switch s.prev {
+	case token.Ident, token.Number, token.BigInt, token.String, token.Regex,
+		token.NoSubstitutionTemplate, token.TemplateTail,
+		token.RParen, token.RBrack, token.RBrace, token.Inc, token.Dec,
+		token.This, token.Super, token.True, token.False, token.Null:
+		return false
+	default:
+		// Contextual keywords are usually identifiers.
+		return !s.prev.IsContextualKeyword()
+	}
+}
+
+// nextRegex scans a regular expression literal. The token text is the whole
+// literal, including the slashes and flags.
+func (s *Scanner) nextRegex() token.Token {
+	s.offset++
+	inClass := false
+
+	for {
+		if s.offset >= len(s.src) {
+			return s.illegal(s.offset, "regular expression literal not terminated")
+		}
+		c := s.src[s.offset]
+		if c == '/' && !inClass {
+			break
+		}
+		switch c {
+		case '\\':
+			if s.offset+1 < len(s.src) && s.src[s.offset+1] != '\n' && s.src[s.offset+1] != '\r' {
+				s.offset++
+			}
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '\n', '\r':
+			return s.illegal(s.offset, "regular expression literal not terminated")
+		default:
+		}
+		s.offset++
+	}
+	s.offset++ // closing slash
+
+	flagsStart := s.offset
+	s.skipIdentifierParts()
+	if !isRegexFlags(s.src[flagsStart:s.offset]) {
+		return s.illegal(s.offset, "invalid regular expression flags")
+	}
+
+	return token.Token{Kind: token.Regex, Text: string(s.src[s.start:s.offset])}
+}
+
+// isRegexFlags reports whether flags is a valid set of regular expression
+// flags, with no flag repeated.
+func isRegexFlags(flags []byte) bool {
+	var seen [256]bool
+	for _, c := range flags {
+		if strings.IndexByte("dgimsuvy", c) == -1 || seen[c] {
+			return false
+		}
+		seen[c] = true
+	}
+	// The u and v flags are mutually exclusive.
+	return !(seen['u'] && seen['v'])
+}
+
+func (s *Scanner) nextLineComment() token.Token {
+	s.offset += 2
+	for s.offset+1 < len(s.src) && s.src[s.offset] == ' ' {
+		s.offset++
+	}
+
+	commentStart := s.offset
+
+	for s.offset < len(s.src) {
+		if r, _ := utf8.DecodeRune(s.src[s.offset:]); isLineTerminator(r) {
+			break
+		}
+		s.offset++
+	}
+
+	kind := token.Comment
+	if s.willBeTrailingComment {
+		kind = token.LineComment
+	}
+
+	return token.Token{Kind: kind, Text: string(s.src[commentStart:s.offset])}
+}
+
+func (s *Scanner) nextBlockComment() token.Token {
+	s.offset += 2
+	// A * followed by / closes the comment, as in /**/.
+	for s.offset+1 < len(s.src) && s.src[s.offset] == '*' && s.src[s.offset+1] != '/' {
+		s.offset++
+	}
+
+	innerEndIndex := bytes.Index(s.src[s.offset:], []byte("*/"))
+	if innerEndIndex == -1 {
+		return s.illegal(len(s.src), "comment not terminated")
+	}
+	innerEndIndex += s.offset
+	endIndex := innerEndIndex + 2
+	for innerEndIndex > s.offset && s.src[innerEndIndex-1] == '*' {
+		innerEndIndex--
+	}
+
+	var comment []byte
+
+	for s.offset < innerEndIndex {
+		lineEnd := bytes.Index(s.src[s.offset:innerEndIndex], []byte("\n"))
+		if lineEnd == -1 {
+			comment = append(comment, s.src[s.offset:innerEndIndex]...)
+			break
+		}
+
+		comment = append(comment, s.src[s.offset:s.offset+lineEnd]...)
+		comment = append(comment, '\n')
+		s.offset += lineEnd + 1
+
+		i := bytes.IndexFunc(s.src[s.offset:innerEndIndex], func(r rune) bool {
+			return r != ' ' && r != '\t' && r != '*'
+		})
+		if i == -1 {
+			break
+		}
+
+		s.offset += i
+	}
+
+	s.offset = endIndex
+
+	kind := token.Comment
+	if s.willBeTrailingComment {
+		kind = token.LineComment
+	}
+
+	return token.Token{Kind: kind, Text: string(bytes.TrimSpace(comment))}
+}
+
+// nextString scans a single- or double-quoted string literal. The token text
+// is the raw text between the quotes, with escape sequences left undecoded.
+func (s *Scanner) nextString() token.Token {
+	quote := s.src[s.offset]
+	s.offset++
+	start := s.offset
+
+	for s.offset < len(s.src) {
+		switch s.src[s.offset] {
+		case quote:
+			s.offset++
+			text := string(s.src[start : s.offset-1])
+			if _, ok := DecodeString(text); !ok {
+				return s.illegal(s.offset, "invalid escape sequence in string literal")
+			}
+			return token.Token{Kind: token.String, Text: text}
+		case '\\':
+			s.offset++
+			if s.hasPrefix("\r\n") {
+				s.offset++
+			}
+			s.offset++
+		case '\n', '\r':
+			return s.illegal(s.offset, "string literal not terminated")
+		default:
+			s.offset++
+		}
+	}
+
+	return s.illegal(len(s.src), "string literal not terminated")
+}
+
+// DecodeString returns the value of a string literal given its raw text
+// between the quotes, as returned by [Scanner.Scan], and whether all of its
+// escape sequences are valid.
+func DecodeString(raw string) (string, bool) {
+	if strings.IndexByte(raw, '\\') == -1 {
+		return raw, true
+	}
+
+	var b strings.Builder
+	var highSurrogate rune // pending \u escape awaiting its low surrogate
+
+	for i := 0; i < len(raw); {
+		if raw[i] != '\\' {
+			if highSurrogate != 0 {
+				b.WriteRune(utf8.RuneError)
+				highSurrogate = 0
+			}
+			b.WriteByte(raw[i])
+			i++
+			continue
+		}
+
+		r, n, ok := decodeEscape(raw[i+1:])
+		if !ok {
+			return "", false
+		}
+		i += 1 + n
+
+		switch {
+		case r < 0: // line continuation
+			continue
+		case r >= 0xDC00 && r < 0xE000 && highSurrogate != 0:
+			b.WriteRune(utf16.DecodeRune(highSurrogate, r))
+			highSurrogate = 0
+			continue
+		case highSurrogate != 0:
+			b.WriteRune(utf8.RuneError)
+			highSurrogate = 0
+		}
+
+		if r >= 0xD800 && r < 0xDC00 {
+			highSurrogate = r
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	if highSurrogate != 0 {
+		b.WriteRune(utf8.RuneError)
+	}
+
+	return b.String(), true
+}
+
+// decodeEscape decodes the escape sequence at the start of s, which follows a
+// backslash. It returns the decoded rune, or -1 for a line continuation, and
+// the number of bytes consumed.
+func decodeEscape(s string) (r rune, n int, ok bool) {
+	if s == "" {
+		return 0, 0, false
+	}
+
+	switch c := s[0]; c {
+	case 'n':
+		return '\n', 1, true
+	case 'r':
+		return '\r', 1, true
+	case 't':
+		return '\t', 1, true
+	case 'b':
+		return '\b', 1, true
+	case 'f':
+		return '\f', 1, true
+	case 'v':
+		return '\v', 1, true
+	case '0':
+		if len(s) > 1 && s[1] >= '0' && s[1] <= '9' {
+			return 0, 0, false // octal escapes are not allowed
+		}
+		return 0, 1, true
+	case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return 0, 0, false
+	case 'x':
+		return decodeHex(s[1:], 2, 1)
+	case 'u':
+		if strings.HasPrefix(s, "u{") {
+			end := strings.IndexByte(s, '}')
+			if end < 3 {
+				return 0, 0, false
+			}
+			r, _, ok := decodeHex(s[2:end], end-2, 0)
+			if !ok || r > utf8.MaxRune {
+				return 0, 0, false
+			}
+			return r, end + 1, true
+		}
+		return decodeHex(s[1:], 4, 1)
+	case '\n':
+		return -1, 1, true
+	case '\r':
+		if strings.HasPrefix(s, "\r\n") {
+			return -1, 2, true
+		}
+		return -1, 1, true
+	}
+
+	r, n = utf8.DecodeRuneInString(s)
+	if r == '\u2028' || r == '\u2029' {
+		return -1, n, true
+	}
+	return r, n, true
+}
+
+// decodeHex decodes exactly digits hexadecimal digits at the start of s,
+// returning the value and the number of bytes consumed, plus prefix.
+func decodeHex(s string, digits, prefix int) (r rune, n int, ok bool) {
+	if digits == 0 || len(s) < digits {
+		return 0, 0, false
+	}
+	for i := 0; i < digits; i++ {
+		var d byte
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			d = c - '0'
+		case c >= 'a' && c <= 'f':
+			d = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			d = c - 'A' + 10
+		default:
+			return 0, 0, false
+		}
+		if r > utf8.MaxRune {
+			return 0, 0, false
+		}
+		r = r<<4 | rune(d)
+	}
+	return r, digits + prefix, true
+}
+
+// nextTemplate scans a template literal from its opening backtick up to its
+// closing backtick or first substitution.
+func (s *Scanner) nextTemplate() token.Token {
+	s.offset++
+	return s.scanTemplate(token.NoSubstitutionTemplate, token.TemplateHead)
+}
+
+// nextTemplateContinuation scans the part of a template literal following
+// the closing brace of a substitution.
+func (s *Scanner) nextTemplateContinuation() token.Token {
+	last := len(s.templateBraceDepths) - 1
+	s.braceDepth = s.templateBraceDepths[last]
+	s.templateBraceDepths = s.templateBraceDepths[:last]
+	s.offset++
+	return s.scanTemplate(token.TemplateTail, token.TemplateMiddle)
+}
+
+// scanTemplate scans template text up to and including the closing backtick,
+// returning a token of kind end, or the opening of a substitution, returning
+// a token of kind substitution. The token text excludes the delimiters.
+func (s *Scanner) scanTemplate(end, substitution token.Kind) token.Token {
+	start := s.offset
+	for s.offset < len(s.src) {
+		switch {
+		case s.src[s.offset] == '\\':
+			s.offset += 2
+		case s.src[s.offset] == '`':
+			s.offset++
+			return token.Token{Kind: end, Text: string(s.src[start : s.offset-1])}
+		case s.hasPrefix("${"):
+			s.offset += 2
+			// The slice is copied so that scanner snapshots taken for lookahead
+			// do not share its backing array.
+			depths := s.templateBraceDepths
+			s.templateBraceDepths = append(depths[:len(depths):len(depths)], s.braceDepth)
+			s.braceDepth = 0
+			return token.Token{Kind: substitution, Text: string(s.src[start : s.offset-2])}
+		default:
+			s.offset++
+		}
+	}
+	return s.illegal(len(s.src), "template literal not terminated")
+}
+
+// illegal reports an error at the start of the current token and returns an
+// Illegal token, advancing to end so that scanning can continue after the
+// illegal input.
+func (s *Scanner) illegal(end int, msg string) token.Token {
+	if s.err != nil {
+		s.err(s.pos(s.start), msg)
+	}
+	s.ErrorCount++
+	s.offset = end
+	return token.Token{Kind: token.Illegal}
+}
+
+// longest returns a token of the first of the given kinds whose text begins
+// the remaining source. The kinds are ordered from the longest to the
+// shortest text, so that the longest matching token is returned.
+func (s *Scanner) longest(kinds ...token.Kind) token.Token {
+	for _, kind := range kinds {
+		if text := kind.String(); s.hasPrefix(text) {
+			return s.chars(kind, len(text))
+		}
+	}
+	return s.illegal(s.offset+1, fmt.Sprintf("illegal character %#U", rune(s.src[s.offset])))
+}
+
+func (s *Scanner) char(kind token.Kind) token.Token {
+	return s.chars(kind, 1)
+}
+
+func (s *Scanner) chars(kind token.Kind, n int) token.Token {
+	s.offset += n
+	return token.Token{Kind: kind}
+}
+
+func (s *Scanner) hasPrefix(prefix string) bool {
+	return bytes.HasPrefix(s.src[s.offset:], []byte(prefix))
+}
+
+// nextNumber scans a numeric or bigint literal, including base prefixes,
+// fractions, exponents and numeric separators.
+func (s *Scanner) nextNumber() token.Token {
+	start := s.offset
+	isInteger := true
+
+	if s.src[s.offset] == '0' && s.offset+1 < len(s.src) {
+		var isDigit func(byte) bool
+		switch s.src[s.offset+1] {
+		case 'x', 'X':
+			isDigit = isHexDigit
+		case 'b', 'B':
+			isDigit = func(c byte) bool { return c == '0' || c == '1' }
+		case 'o', 'O':
+			isDigit = func(c byte) bool { return c >= '0' && c <= '7' }
+		}
+		if isDigit != nil {
+			s.offset += 2
+			if !s.scanDigits(isDigit) {
+				return s.illegalNumber()
+			}
+			return s.numberSuffix(start, true)
+		}
+	}
+
+	if s.src[s.offset] != '.' && !s.scanDigits(isDecimalDigit) {
+		return s.illegalNumber()
+	}
+
+	if s.offset < len(s.src) && s.src[s.offset] == '.' {
+		isInteger = false
+		s.offset++
+		if s.offset < len(s.src) && isDecimalDigit(s.src[s.offset]) && !s.scanDigits(isDecimalDigit) {
+			return s.illegalNumber()
+		}
+	}
+
+	if s.offset < len(s.src) && (s.src[s.offset] == 'e' || s.src[s.offset] == 'E') {
+		isInteger = false
+		s.offset++
+		if s.offset < len(s.src) && (s.src[s.offset] == '+' || s.src[s.offset] == '-') {
+			s.offset++
+		}
+		if !s.scanDigits(isDecimalDigit) {
+			return s.illegalNumber()
+		}
+	}
+
+	return s.numberSuffix(start, isInteger)
+}
+
+// scanDigits scans one or more digits, which may be separated by single
+// underscores, and reports whether they were well formed.
+func (s *Scanner) scanDigits(isDigit func(byte) bool) bool {
+	start := s.offset
+	for s.offset < len(s.src) {
+		c := s.src[s.offset]
+		if c == '_' {
+			if s.offset == start || s.src[s.offset-1] == '_' {
+				return false
+			}
+		} else if !isDigit(c) {
+			break
+		}
+		s.offset++
+	}
+	return s.offset > start && s.src[s.offset-1] != '_'
+}
+
+// numberSuffix scans the optional bigint suffix of the numeric literal
+// starting at start and returns its token.
+func (s *Scanner) numberSuffix(start int, isInteger bool) token.Token {
+	kind := token.Number
+	if isInteger && s.offset < len(s.src) && s.src[s.offset] == 'n' {
+		kind = token.BigInt
+		s.offset++
+	}
+
+	// A numeric literal must not be immediately followed by an identifier or
+	// another digit.
+	if r, _ := utf8.DecodeRune(s.src[s.offset:]); isIdentifierPart(r) || r == '\\' {
+		return s.illegalNumber()
+	}
+
+	return token.Token{Kind: kind, Text: string(s.src[start:s.offset])}
+}
+
+// illegalNumber returns an Illegal token for a malformed numeric literal,
+// skipping the rest of it.
+func (s *Scanner) illegalNumber() token.Token {
+	s.skipIdentifierParts()
+	for s.offset < len(s.src) && s.src[s.offset] == '.' {
+		s.offset++
+		s.skipIdentifierParts()
+	}
+	return s.illegal(s.offset, "invalid numeric literal")
+}
+
+func isDecimalDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDecimalDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// nextIdent scans an identifier or keyword. Unicode escape sequences in the
+// identifier are decoded in the token text.
+func (s *Scanner) nextIdent() token.Token {
+	start := s.offset
+	var text []byte // decoded text, only allocated once an escape is seen
+
+	for s.offset < len(s.src) {
+		r, size := utf8.DecodeRune(s.src[s.offset:])
+		escaped := r == '\\'
+		if escaped {
+			var n int
+			var ok bool
+			r, n, ok = decodeEscape(string(s.src[s.offset+1 : s.offsetOfIdentEscapeEnd()]))
+			if !ok || s.src[s.offset+1] != 'u' {
+				return s.illegalIdent()
+			}
+			size = 1 + n
+		}
+
+		valid := isIdentifierPart(r)
+		if s.offset == start {
+			valid = isIdentifierStart(r)
+		}
+		if !valid {
+			if escaped {
+				return s.illegalIdent()
+			}
+			break
+		}
+
+		switch {
+		case escaped && text == nil:
+			text = append([]byte{}, s.src[start:s.offset]...)
+			text = utf8.AppendRune(text, r)
+		case escaped:
+			text = utf8.AppendRune(text, r)
+		case text != nil:
+			text = append(text, s.src[s.offset:s.offset+size]...)
+		}
+		s.offset += size
+	}
+
+	// An identifier containing escape sequences is never a keyword.
+	if text != nil {
+		return token.Token{Kind: token.Ident, Text: string(text)}
+	}
+
+	ident := string(s.src[start:s.offset])
+	return token.Token{Kind: token.Lookup(ident), Text: ident}
+}
+
+// offsetOfIdentEscapeEnd returns the offset at which a unicode escape
+// sequence starting at the current offset must end.
+func (s *Scanner) offsetOfIdentEscapeEnd() int {
+	end := s.offset + len(`\u0000`)
+	if s.hasPrefix(`\u{`) {
+		if i := bytes.IndexByte(s.src[s.offset:], '}'); i != -1 {
+			end = s.offset + i + 1
+		}
+	}
+	if end > len(s.src) {
+		end = len(s.src)
+	}
+	return end
+}
+
+// illegalIdent returns an Illegal token for an identifier containing an
+// invalid escape sequence, skipping the rest of it.
+func (s *Scanner) illegalIdent() token.Token {
+	if s.hasPrefix(`\u{`) {
+		s.offset = s.offsetOfIdentEscapeEnd()
+	} else {
+		s.offset++
+	}
+	s.skipIdentifierParts()
+	return s.illegal(s.offset, "invalid escape sequence in identifier")
+}
+
+// skipIdentifierParts advances past any identifier characters, including
+// backslashes that may begin escape sequences.
+func (s *Scanner) skipIdentifierParts() {
+	for s.offset < len(s.src) {
+		r, size := utf8.DecodeRune(s.src[s.offset:])
+		if !isIdentifierPart(r) && r != '\\' {
+			break
+		}
+		s.offset += size
+	}
+}
+
+// isWhitespace reports whether r is whitespace, per the ECMAScript
+// WhiteSpace production. This includes the byte order mark, so that a
+// leading BOM is skipped.
+func isWhitespace(r rune) bool {
+	switch r {
+	case '\t', '\v', '\f', ' ', '\u00A0', '\uFEFF':
+		return true
+	default:
+		return r >= utf8.RuneSelf && unicode.Is(unicode.Zs, r)
+	}
+}
+
+// isLineTerminator reports whether r ends a line, per the ECMAScript
+// LineTerminator production.
+func isLineTerminator(r rune) bool {
+	return r == '\n' || r == '\r' || r == '\u2028' || r == '\u2029'
+}
+
+// isIdentifierStart reports whether r may begin an identifier, per the
+// ECMAScript IdentifierStart production.
+func isIdentifierStart(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '$', r == '_':
+		return true
+	case r < utf8.RuneSelf:
+		return false
+	default:
+		return unicode.In(r, unicode.L, unicode.Nl, unicode.Other_ID_Start)
+	}
+}
+
+// isIdentifierPart reports whether r may continue an identifier, per the
+// ECMAScript IdentifierPart production.
+func isIdentifierPart(r rune) bool {
+	switch {
+	case isIdentifierStart(r), r >= '0' && r <= '9':
+		return true
+	case r < utf8.RuneSelf:
+		return false
+	case r == '\u200C', r == '\u200D': // ZWNJ and ZWJ
+		return true
+	default:
+		return unicode.In(r, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc, unicode.Other_ID_Continue)
+	}
+}
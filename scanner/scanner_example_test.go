@@ -0,0 +1,31 @@
+package scanner_test
+
+import (
+	"fmt"
+
+	"github.com/armsnyder/typescript-ast-go/scanner"
+	"github.com/armsnyder/typescript-ast-go/token"
+)
+
+func ExampleScanner_Scan() {
+	src := []byte("const answer = 42; // the answer")
+
+	var s scanner.Scanner
+	s.Init(src, nil, scanner.ScanComments)
+
+	for {
+		pos, kind, lit := s.Scan()
+		if kind == token.EOF {
+			break
+		}
+		fmt.Printf("%s\t%s\t%q\n", pos, kind, lit)
+	}
+
+	// Output:
+	// 1:1	const	"const"
+	// 1:7	Ident	"answer"
+	// 1:14	=	""
+	// 1:16	Number	"42"
+	// 1:18	;	""
+	// 1:20	LineComment	"the answer"
+}
@@ -1,14 +1,22 @@
-package parser
+package scanner
 
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/armsnyder/typescript-ast-go/token"
 )
 
-func TestLexer(t *testing.T) {
+// scanToken scans the next token from s.
+func scanToken(s *Scanner) token.Token {
+	pos, kind, lit := s.Scan()
+	return token.Token{Kind: kind, Text: lit, Pos: pos, End: s.End()}
+}
+
+func TestScanner(t *testing.T) {
 	tests := []struct {
 		testdata string
 		want     []token.Token
@@ -17,8 +25,8 @@ func TestLexer(t *testing.T) {
 			testdata: "array",
 			want: []token.Token{
 				{Kind: token.Comment, Text: "LSP arrays.\n\n@since 3.17.0"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "type"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Type, Text: "type"},
 				{Kind: token.Ident, Text: "LSPArray"},
 				{Kind: token.Assign},
 				{Kind: token.Ident, Text: "LSPAny"},
@@ -31,8 +39,8 @@ func TestLexer(t *testing.T) {
 			testdata: "basic_type",
 			want: []token.Token{
 				{Kind: token.Comment, Text: "Defines an integer number in the range of -2^31 to 2^31 - 1."},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "type"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Type, Text: "type"},
 				{Kind: token.Ident, Text: "integer"},
 				{Kind: token.Assign},
 				{Kind: token.Ident, Text: "number"},
@@ -42,28 +50,28 @@ func TestLexer(t *testing.T) {
 		{
 			testdata: "enum",
 			want: []token.Token{
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "enum"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Enum, Text: "enum"},
 				{Kind: token.Ident, Text: "SemanticTokenTypes"},
 				{Kind: token.LBrace},
-				{Kind: token.Ident, Text: "namespace"},
+				{Kind: token.Namespace, Text: "namespace"},
 				{Kind: token.Assign},
 				{Kind: token.String, Text: "namespace"},
 				{Kind: token.Comma},
 				{Kind: token.Comment, Text: "Represents a generic type. Acts as a fallback for types which\ncan't be mapped to a specific type like class or enum."},
-				{Kind: token.Ident, Text: "type"},
+				{Kind: token.Type, Text: "type"},
 				{Kind: token.Assign},
 				{Kind: token.String, Text: "type"},
 				{Kind: token.Comma},
-				{Kind: token.Ident, Text: "class"},
+				{Kind: token.Class, Text: "class"},
 				{Kind: token.Assign},
 				{Kind: token.String, Text: "class"},
 				{Kind: token.Comma},
-				{Kind: token.Ident, Text: "enum"},
+				{Kind: token.Enum, Text: "enum"},
 				{Kind: token.Assign},
 				{Kind: token.String, Text: "enum"},
 				{Kind: token.Comma},
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Assign},
 				{Kind: token.String, Text: "interface"},
 				{Kind: token.Comma},
@@ -77,7 +85,7 @@ func TestLexer(t *testing.T) {
 		{
 			testdata: "generic",
 			want: []token.Token{
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Ident, Text: "ProgressParams"},
 				{Kind: token.LAngle},
 				{Kind: token.Ident, Text: "T"},
@@ -99,7 +107,7 @@ func TestLexer(t *testing.T) {
 		{
 			testdata: "inline_type",
 			want: []token.Token{
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Ident, Text: "HoverParams"},
 				{Kind: token.LBrace},
 				{Kind: token.Ident, Text: "textDocument"},
@@ -126,9 +134,9 @@ func TestLexer(t *testing.T) {
 		{
 			testdata: "interface",
 			want: []token.Token{
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Ident, Text: "ResponseMessage"},
-				{Kind: token.Ident, Text: "extends"},
+				{Kind: token.Extends, Text: "extends"},
 				{Kind: token.Ident, Text: "Message"},
 				{Kind: token.LBrace},
 				{Kind: token.Comment, Text: "The request id."},
@@ -138,7 +146,7 @@ func TestLexer(t *testing.T) {
 				{Kind: token.Or},
 				{Kind: token.Ident, Text: "string"},
 				{Kind: token.Or},
-				{Kind: token.Ident, Text: "null"},
+				{Kind: token.Null, Text: "null"},
 				{Kind: token.Semicolon},
 				{Kind: token.Comment, Text: "The result of a request. This member is REQUIRED on success.\nThis member MUST NOT exist if there was an error invoking the method."},
 				{Kind: token.Ident, Text: "result"},
@@ -154,7 +162,7 @@ func TestLexer(t *testing.T) {
 				{Kind: token.Or},
 				{Kind: token.Ident, Text: "object"},
 				{Kind: token.Or},
-				{Kind: token.Ident, Text: "null"},
+				{Kind: token.Null, Text: "null"},
 				{Kind: token.Semicolon},
 				{Kind: token.Comment, Text: "The error object in case a request fails."},
 				{Kind: token.Ident, Text: "error"},
@@ -168,8 +176,8 @@ func TestLexer(t *testing.T) {
 		{
 			testdata: "interface_complex_syntax",
 			want: []token.Token{
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Ident, Text: "WorkspaceEdit"},
 				{Kind: token.LBrace},
 				{Kind: token.Comment, Text: "Holds changes to existing resources."},
@@ -233,8 +241,8 @@ func TestLexer(t *testing.T) {
 		{
 			testdata: "interface_with_union_array",
 			want: []token.Token{
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Ident, Text: "TextDocumentEdit"},
 				{Kind: token.LBrace},
 				{Kind: token.Comment, Text: "The edits to be applied.\n\n@since 3.16.0 - support for AnnotatedTextEdit. This is guarded by the\nclient capability `workspace.workspaceEdit.changeAnnotationSupport`"},
@@ -255,8 +263,8 @@ func TestLexer(t *testing.T) {
 			testdata: "interface_with_union_struct_field",
 			want: []token.Token{
 				{Kind: token.Comment, Text: "Options specific to a notebook plus its cells\nto be synced to the server.\n\nIf a selector provides a notebook document\nfilter but no cell selector all cells of a\nmatching notebook document will be synced.\n\nIf a selector provides no notebook document\nfilter but only a cell selector all notebook\ndocuments that contain at least one matching\ncell will be synced.\n\n@since 3.17.0"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Ident, Text: "NotebookDocumentSyncOptions"},
 				{Kind: token.LBrace},
 				{Kind: token.Comment, Text: "The notebooks to be synced"},
@@ -323,10 +331,10 @@ func TestLexer(t *testing.T) {
 			testdata: "multiple_extends",
 			want: []token.Token{
 				{Kind: token.Comment, Text: "Registration options specific to a notebook.\n\n@since 3.17.0"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Ident, Text: "NotebookDocumentSyncRegistrationOptions"},
-				{Kind: token.Ident, Text: "extends"},
+				{Kind: token.Extends, Text: "extends"},
 				{Kind: token.Ident, Text: "NotebookDocumentSyncOptions"},
 				{Kind: token.Comma},
 				{Kind: token.Ident, Text: "StaticRegistrationOptions"},
@@ -337,13 +345,13 @@ func TestLexer(t *testing.T) {
 		{
 			testdata: "namespace",
 			want: []token.Token{
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "namespace"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Namespace, Text: "namespace"},
 				{Kind: token.Ident, Text: "ErrorCodes"},
 				{Kind: token.LBrace},
 				{Kind: token.Comment, Text: "Defined by JSON-RPC"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "const"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Const, Text: "const"},
 				{Kind: token.Ident, Text: "ParseError"},
 				{Kind: token.Colon},
 				{Kind: token.Ident, Text: "integer"},
@@ -351,8 +359,8 @@ func TestLexer(t *testing.T) {
 				{Kind: token.Minus},
 				{Kind: token.Number, Text: "32700"},
 				{Kind: token.Semicolon},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "const"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Const, Text: "const"},
 				{Kind: token.Ident, Text: "InvalidRequest"},
 				{Kind: token.Colon},
 				{Kind: token.Ident, Text: "integer"},
@@ -361,8 +369,8 @@ func TestLexer(t *testing.T) {
 				{Kind: token.Number, Text: "32600"},
 				{Kind: token.Semicolon},
 				{Kind: token.Comment, Text: "This is the start range of JSON-RPC reserved error codes.\nIt doesn't denote a real error code. No LSP error codes should\nbe defined between the start and end range. For backwards\ncompatibility the `ServerNotInitialized` and the `UnknownErrorCode`\nare left in the range.\n\n@since 3.16.0"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "const"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Const, Text: "const"},
 				{Kind: token.Ident, Text: "jsonrpcReservedErrorRangeStart"},
 				{Kind: token.Colon},
 				{Kind: token.Ident, Text: "integer"},
@@ -371,8 +379,8 @@ func TestLexer(t *testing.T) {
 				{Kind: token.Number, Text: "32099"},
 				{Kind: token.Semicolon},
 				{Kind: token.Comment, Text: "@deprecated use jsonrpcReservedErrorRangeStart"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "const"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Const, Text: "const"},
 				{Kind: token.Ident, Text: "serverErrorStart"},
 				{Kind: token.Colon},
 				{Kind: token.Ident, Text: "integer"},
@@ -386,8 +394,8 @@ func TestLexer(t *testing.T) {
 			testdata: "object",
 			want: []token.Token{
 				{Kind: token.Comment, Text: "LSP object definition.\n\n@since 3.17.0"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "type"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Type, Text: "type"},
 				{Kind: token.Ident, Text: "LSPObject"},
 				{Kind: token.Assign},
 				{Kind: token.LBrace},
@@ -405,11 +413,11 @@ func TestLexer(t *testing.T) {
 		{
 			testdata: "readonly",
 			want: []token.Token{
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "interface"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Interface, Text: "interface"},
 				{Kind: token.Ident, Text: "SemanticTokensDelta"},
 				{Kind: token.LBrace},
-				{Kind: token.Ident, Text: "readonly"},
+				{Kind: token.Readonly, Text: "readonly"},
 				{Kind: token.Ident, Text: "resultId"},
 				{Kind: token.Question},
 				{Kind: token.Colon},
@@ -429,8 +437,8 @@ func TestLexer(t *testing.T) {
 			testdata: "union_struct",
 			want: []token.Token{
 				{Kind: token.Comment, Text: "A notebook document filter denotes a notebook document by\ndifferent properties.\n\n@since 3.17.0"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "type"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Type, Text: "type"},
 				{Kind: token.Ident, Text: "NotebookDocumentFilter"},
 				{Kind: token.Assign},
 				{Kind: token.LBrace},
@@ -500,8 +508,8 @@ func TestLexer(t *testing.T) {
 			// [Comment("The LSP any type\n\n@since 3.17.0") Ident, Text: "export" Type Ident("LSPAny") Assign Ident("LSPObject") Or Ident("LSPArray") Or Ident("string") Or Ident("integer") Or Ident("uinteger") Or Ident("decimal") Or Ident("boolean") Or Ident("null") Semicolon]
 			want: []token.Token{
 				{Kind: token.Comment, Text: "The LSP any type\n\n@since 3.17.0"},
-				{Kind: token.Ident, Text: "export"},
-				{Kind: token.Ident, Text: "type"},
+				{Kind: token.Export, Text: "export"},
+				{Kind: token.Type, Text: "type"},
 				{Kind: token.Ident, Text: "LSPAny"},
 				{Kind: token.Assign},
 				{Kind: token.Ident, Text: "LSPObject"},
@@ -518,7 +526,7 @@ func TestLexer(t *testing.T) {
 				{Kind: token.Or},
 				{Kind: token.Ident, Text: "boolean"},
 				{Kind: token.Or},
-				{Kind: token.Ident, Text: "null"},
+				{Kind: token.Null, Text: "null"},
 				{Kind: token.Semicolon},
 			},
 		},
@@ -531,14 +539,16 @@ func TestLexer(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			lex := lexer{Source: source}
+			var s Scanner
+			s.Init(source, nil, ScanComments)
 
 			var got []token.Token
 			for {
-				tok := lex.Pop()
+				tok := scanToken(&s)
 				if tok.Kind == token.EOF {
 					break
 				}
+				tok.Pos, tok.End = token.Pos{}, token.Pos{}
 				got = append(got, tok)
 				if tok.Kind == token.Illegal {
 					break
@@ -557,3 +567,512 @@ func TestLexer(t *testing.T) {
 		})
 	}
 }
+
+func TestScanner_Inline(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []token.Token
+	}{
+		{
+			name: "function type",
+			src:  "(...a) => b.c",
+			want: []token.Token{
+				{Kind: token.LParen},
+				{Kind: token.Ellipsis},
+				{Kind: token.Ident, Text: "a"},
+				{Kind: token.RParen},
+				{Kind: token.Arrow},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.Dot},
+				{Kind: token.Ident, Text: "c"},
+			},
+		},
+		{
+			name: "assign",
+			src:  "a = b",
+			want: []token.Token{
+				{Kind: token.Ident, Text: "a"},
+				{Kind: token.Assign},
+				{Kind: token.Ident, Text: "b"},
+			},
+		},
+		{
+			name: "intersection",
+			src:  "A & B",
+			want: []token.Token{
+				{Kind: token.Ident, Text: "A"},
+				{Kind: token.And},
+				{Kind: token.Ident, Text: "B"},
+			},
+		},
+		{
+			name: "namespace import",
+			src:  "import * as ns",
+			want: []token.Token{
+				{Kind: token.Import, Text: "import"},
+				{Kind: token.Star},
+				{Kind: token.As, Text: "as"},
+				{Kind: token.Ident, Text: "ns"},
+			},
+		},
+		{
+			name: "template literals",
+			src:  "`a` `b${c}d${ {e: `f${g}`} }h`",
+			want: []token.Token{
+				{Kind: token.NoSubstitutionTemplate, Text: "a"},
+				{Kind: token.TemplateHead, Text: "b"},
+				{Kind: token.Ident, Text: "c"},
+				{Kind: token.TemplateMiddle, Text: "d"},
+				{Kind: token.LBrace},
+				{Kind: token.Ident, Text: "e"},
+				{Kind: token.Colon},
+				{Kind: token.TemplateHead, Text: "f"},
+				{Kind: token.Ident, Text: "g"},
+				{Kind: token.TemplateTail},
+				{Kind: token.RBrace},
+				{Kind: token.TemplateTail, Text: "h"},
+			},
+		},
+		{
+			name: "whitespace",
+			src:  "\uFEFF// a\nb\v\fc\u00A0d\u3000e /* f */\u2028// g",
+			want: []token.Token{
+				{Kind: token.Comment, Text: "a"},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.Ident, Text: "c"},
+				{Kind: token.Ident, Text: "d"},
+				{Kind: token.Ident, Text: "e"},
+				{Kind: token.LineComment, Text: "f"},
+				{Kind: token.Comment, Text: "g"},
+			},
+		},
+		{
+			name: "stray closing brace before template",
+			src:  "} { `a${b}c` }",
+			want: []token.Token{
+				{Kind: token.RBrace},
+				{Kind: token.LBrace},
+				{Kind: token.TemplateHead, Text: "a"},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.TemplateTail, Text: "c"},
+				{Kind: token.RBrace},
+			},
+		},
+		{
+			name: "strings",
+			src: `'it\'s' "say \"hi\"" 'a\
+b' "unterminated`,
+			want: []token.Token{
+				{Kind: token.String, Text: `it\'s`},
+				{Kind: token.String, Text: `say \"hi\"`},
+				{Kind: token.String, Text: "a\\\nb"},
+				{Kind: token.Illegal},
+			},
+		},
+		{
+			name: "string with line break",
+			src:  "'a\nb'",
+			want: []token.Token{
+				{Kind: token.Illegal},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.Illegal},
+			},
+		},
+		{
+			name: "string with invalid escape",
+			src:  `'\x4' a`,
+			want: []token.Token{
+				{Kind: token.Illegal},
+				{Kind: token.Ident, Text: "a"},
+			},
+		},
+		{
+			name: "numbers",
+			src:  "0 1.5 .5 1. 0x1F 0B1010 0o17 1e-3 2.5E+10 1_000_000 10n 0xFFn",
+			want: []token.Token{
+				{Kind: token.Number, Text: "0"},
+				{Kind: token.Number, Text: "1.5"},
+				{Kind: token.Number, Text: ".5"},
+				{Kind: token.Number, Text: "1."},
+				{Kind: token.Number, Text: "0x1F"},
+				{Kind: token.Number, Text: "0B1010"},
+				{Kind: token.Number, Text: "0o17"},
+				{Kind: token.Number, Text: "1e-3"},
+				{Kind: token.Number, Text: "2.5E+10"},
+				{Kind: token.Number, Text: "1_000_000"},
+				{Kind: token.BigInt, Text: "10n"},
+				{Kind: token.BigInt, Text: "0xFFn"},
+			},
+		},
+		{
+			name: "invalid numbers",
+			src:  "1__0 1_ 0x 1e 1.5n 0b12 3in a",
+			want: []token.Token{
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Ident, Text: "a"},
+			},
+		},
+		{
+			name: "identifiers",
+			src:  "$ref _internal $ café 变量 a1 \\u0061b x\\u{62}c a\u200db",
+			want: []token.Token{
+				{Kind: token.Ident, Text: "$ref"},
+				{Kind: token.Ident, Text: "_internal"},
+				{Kind: token.Ident, Text: "$"},
+				{Kind: token.Ident, Text: "café"},
+				{Kind: token.Ident, Text: "变量"},
+				{Kind: token.Ident, Text: "a1"},
+				{Kind: token.Ident, Text: "ab"},
+				{Kind: token.Ident, Text: "xbc"},
+				{Kind: token.Ident, Text: "a\u200db"},
+			},
+		},
+		{
+			name: "invalid identifiers",
+			src:  "\\u0031a a\\x41 b\\u{zz} ∑ c",
+			want: []token.Token{
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Illegal},
+				{Kind: token.Ident, Text: "c"},
+			},
+		},
+		{
+			name: "operators",
+			src:  "a ?? b?.c ?.5 : d >>>= e >> f >= g === h !== !i ** j **= k && l ||= m @n #o ~p % q / r /= s",
+			want: []token.Token{
+				{Kind: token.Ident, Text: "a"},
+				{Kind: token.Nullish},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.QuestionDot},
+				{Kind: token.Ident, Text: "c"},
+				{Kind: token.Question},
+				{Kind: token.Number, Text: ".5"},
+				{Kind: token.Colon},
+				{Kind: token.Ident, Text: "d"},
+				{Kind: token.UShrAssign},
+				{Kind: token.Ident, Text: "e"},
+				{Kind: token.Shr},
+				{Kind: token.Ident, Text: "f"},
+				{Kind: token.GreaterEq},
+				{Kind: token.Ident, Text: "g"},
+				{Kind: token.StrictEq},
+				{Kind: token.Ident, Text: "h"},
+				{Kind: token.StrictNotEq},
+				{Kind: token.Not},
+				{Kind: token.Ident, Text: "i"},
+				{Kind: token.StarStar},
+				{Kind: token.Ident, Text: "j"},
+				{Kind: token.StarStarAssign},
+				{Kind: token.Ident, Text: "k"},
+				{Kind: token.LogicalAnd},
+				{Kind: token.Ident, Text: "l"},
+				{Kind: token.LogicalOrAssign},
+				{Kind: token.Ident, Text: "m"},
+				{Kind: token.At},
+				{Kind: token.Ident, Text: "n"},
+				{Kind: token.Hash},
+				{Kind: token.Ident, Text: "o"},
+				{Kind: token.Tilde},
+				{Kind: token.Ident, Text: "p"},
+				{Kind: token.Percent},
+				{Kind: token.Ident, Text: "q"},
+				{Kind: token.Slash},
+				{Kind: token.Ident, Text: "r"},
+				{Kind: token.SlashAssign},
+				{Kind: token.Ident, Text: "s"},
+			},
+		},
+		{
+			name: "increment and decrement",
+			src:  "++a-- + +b - -c",
+			want: []token.Token{
+				{Kind: token.Inc},
+				{Kind: token.Ident, Text: "a"},
+				{Kind: token.Dec},
+				{Kind: token.Plus},
+				{Kind: token.Plus},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.Minus},
+				{Kind: token.Minus},
+				{Kind: token.Ident, Text: "c"},
+			},
+		},
+		{
+			name: "regular expressions",
+			src:  "a = /[/]\\//g; b(/=/, c / d /= e)",
+			want: []token.Token{
+				{Kind: token.Ident, Text: "a"},
+				{Kind: token.Assign},
+				{Kind: token.Regex, Text: "/[/]\\//g"},
+				{Kind: token.Semicolon},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.LParen},
+				{Kind: token.Regex, Text: "/=/"},
+				{Kind: token.Comma},
+				{Kind: token.Ident, Text: "c"},
+				{Kind: token.Slash},
+				{Kind: token.Ident, Text: "d"},
+				{Kind: token.SlashAssign},
+				{Kind: token.Ident, Text: "e"},
+				{Kind: token.RParen},
+			},
+		},
+		{
+			name: "division after expressions",
+			src:  "(a) / b[0] / 1 / this / c++ / 2",
+			want: []token.Token{
+				{Kind: token.LParen},
+				{Kind: token.Ident, Text: "a"},
+				{Kind: token.RParen},
+				{Kind: token.Slash},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.LBrack},
+				{Kind: token.Number, Text: "0"},
+				{Kind: token.RBrack},
+				{Kind: token.Slash},
+				{Kind: token.Number, Text: "1"},
+				{Kind: token.Slash},
+				{Kind: token.This, Text: "this"},
+				{Kind: token.Slash},
+				{Kind: token.Ident, Text: "c"},
+				{Kind: token.Inc},
+				{Kind: token.Slash},
+				{Kind: token.Number, Text: "2"},
+			},
+		},
+		{
+			name: "invalid regular expressions",
+			src:  "= /a/gg; = /b\n= /c/x",
+			want: []token.Token{
+				{Kind: token.Assign},
+				{Kind: token.Illegal},
+				{Kind: token.Semicolon},
+				{Kind: token.Assign},
+				{Kind: token.Illegal},
+				{Kind: token.Assign},
+				{Kind: token.Illegal},
+			},
+		},
+		{
+			name: "empty block comments",
+			src:  "/**/ a /***/ b /** x **/",
+			want: []token.Token{
+				{Kind: token.Comment},
+				{Kind: token.Ident, Text: "a"},
+				{Kind: token.LineComment},
+				{Kind: token.Ident, Text: "b"},
+				{Kind: token.LineComment, Text: "x"},
+			},
+		},
+		{
+			name: "template literal escapes",
+			src:  "`\\`\\${`",
+			want: []token.Token{
+				{Kind: token.NoSubstitutionTemplate, Text: "\\`\\${"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Scanner
+			s.Init([]byte(tt.src), nil, ScanComments)
+
+			var got []token.Token
+			for {
+				tok := scanToken(&s)
+				if tok.Kind == token.EOF {
+					break
+				}
+				tok.Pos, tok.End = token.Pos{}, token.Pos{}
+				got = append(got, tok)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\ngot:\n%v\nwant:\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_Positions(t *testing.T) {
+	var s Scanner
+	s.Init([]byte("type A =\n\t'a' | // b\n\tC;"), nil, ScanComments)
+
+	var got []string
+	for {
+		tok := scanToken(&s)
+		got = append(got, fmt.Sprintf("%s %s-%s", tok.Kind, tok.Pos, tok.End))
+		if tok.Kind == token.EOF {
+			break
+		}
+	}
+
+	want := []string{
+		"type 1:1-1:5",
+		"Ident 1:6-1:7",
+		"= 1:8-1:9",
+		"String 2:2-2:5",
+		"| 2:6-2:7",
+		"LineComment 2:8-2:12",
+		"Ident 3:2-3:3",
+		"; 3:3-3:4",
+		"EOF 3:4-3:4",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot:\n%s\n\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestScanner_Positions_LineTerminators(t *testing.T) {
+	var s Scanner
+	s.Init([]byte("a\rb\r\nc\u2028d\u2029e // f\rg"), nil, ScanComments)
+
+	var got []string
+	for {
+		tok := scanToken(&s)
+		got = append(got, fmt.Sprintf("%s %s-%s", tok.Kind, tok.Pos, tok.End))
+		if tok.Kind == token.EOF {
+			break
+		}
+	}
+
+	want := []string{
+		"Ident 1:1-1:2",
+		"Ident 2:1-2:2",
+		"Ident 3:1-3:2",
+		"Ident 4:1-4:2",
+		"Ident 5:1-5:2",
+		"LineComment 5:3-5:7",
+		"Ident 6:1-6:2",
+		"EOF 6:2-6:2",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot:\n%s\n\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDecodeString(t *testing.T) {
+	tests := []struct {
+		raw    string
+		want   string
+		wantOK bool
+	}{
+		{raw: `plain`, want: "plain", wantOK: true},
+		{raw: `\n\r\t\b\f\v\0`, want: "\n\r\t\b\f\v\x00", wantOK: true},
+		{raw: `\'\"\\\q`, want: `'"\q`, wantOK: true},
+		{raw: `\x41\u0042\u{43}\u{1F600}`, want: "ABC\U0001F600", wantOK: true},
+		{raw: `\uD83D\uDE00`, want: "\U0001F600", wantOK: true},
+		{raw: `\uD83Dx`, want: "\uFFFDx", wantOK: true},
+		{raw: "a\\\nb\\\r\nc\\\u2028d", want: "abcd", wantOK: true},
+		{raw: `\x4`, wantOK: false},
+		{raw: `\u{}`, wantOK: false},
+		{raw: `\u{110000}`, wantOK: false},
+		{raw: `\u12`, wantOK: false},
+		{raw: `\01`, wantOK: false},
+		{raw: `\1`, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, ok := DecodeString(tt.raw)
+			if ok != tt.wantOK {
+				t.Fatalf("got ok %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_Mode(t *testing.T) {
+	src := "let a = 1; // one\n/* two */\tb"
+
+	tests := []struct {
+		name string
+		mode Mode
+		want []string
+	}{
+		{
+			name: "default",
+			mode: 0,
+			want: []string{`let "let"`, `Ident "a"`, `= ""`, `Number "1"`, `; ""`, `Ident "b"`},
+		},
+		{
+			name: "comments",
+			mode: ScanComments,
+			want: []string{`let "let"`, `Ident "a"`, `= ""`, `Number "1"`, `; ""`, `LineComment "one"`, `Comment "two"`, `Ident "b"`},
+		},
+		{
+			name: "whitespace",
+			mode: ScanWhitespace,
+			want: []string{
+				`let "let"`, `Whitespace " "`, `Ident "a"`, `Whitespace " "`, `= ""`, `Whitespace " "`, `Number "1"`,
+				`; ""`, `Whitespace " "`, `Whitespace "\n"`, `Whitespace "\t"`, `Ident "b"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Scanner
+			s.Init([]byte(src), nil, tt.mode)
+
+			var got []string
+			for {
+				_, kind, lit := s.Scan()
+				if kind == token.EOF {
+					break
+				}
+				got = append(got, fmt.Sprintf("%s %q", kind, lit))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\ngot:\n%s\n\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestScanner_ErrorHandler(t *testing.T) {
+	src := "a ¤ 'b\n0x = /d\n`e"
+
+	var got []string
+	var s Scanner
+	s.Init([]byte(src), func(pos token.Pos, msg string) {
+		got = append(got, fmt.Sprintf("%s: %s", pos, msg))
+	}, 0)
+
+	for {
+		if _, kind, _ := s.Scan(); kind == token.EOF {
+			break
+		}
+	}
+
+	want := []string{
+		"1:3: illegal character U+00A4 '¤'",
+		"1:6: string literal not terminated",
+		"2:1: invalid numeric literal",
+		"2:6: regular expression literal not terminated",
+		"3:1: template literal not terminated",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\ngot:\n%s\n\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if s.ErrorCount != len(want) {
+		t.Errorf("got ErrorCount %d, want %d", s.ErrorCount, len(want))
+	}
+}
@@ -0,0 +1,29 @@
+package token
+
+import "strconv"
+
+// Pos describes a position in a source file, including the byte offset, line,
+// and column. The zero value is not a valid position.
+type Pos struct {
+	Offset int // byte offset, starting at 0
+	Line   int // line number, starting at 1
+	Column int // column number, starting at 1 (byte count)
+}
+
+// NoPos is the zero value for Pos. It does not describe a position in a
+// source file.
+var NoPos Pos
+
+// IsValid reports whether the position is valid.
+func (p Pos) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns the position in the form "line:column", or "-" if the
+// position is not valid.
+func (p Pos) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
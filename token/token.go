@@ -12,20 +12,71 @@ const (
 	// Special tokens.
 	Illegal Kind = iota
 	EOF
+	Whitespace // spaces, tabs and line breaks
 
 	// Comments.
 	Comment     // // or /* */ at the beginning of a line
 	LineComment // // or /* */ at the end of a line
 
 	// Identifiers and literals.
-	Ident  // main, const, extends, etc.
+	Ident  // main, foo, $bar, etc.; keywords have their own kinds
 	Number // 12345
+	BigInt // 12345n
 	String // "abc"
+	Regex  // /abc/g
 
+	// Template literals.
+	NoSubstitutionTemplate // `abc`
+	TemplateHead           // `abc${
+	TemplateMiddle         // }abc${
+	TemplateTail           // }abc`
+
+	operatorBeg
 	// Operators.
-	Or     // |
-	Assign // =
-	Minus  // -
+	Or               // |
+	And              // &
+	Assign           // =
+	Minus            // -
+	Plus             // +
+	Arrow            // =>
+	Ellipsis         // ...
+	Star             // *
+	Slash            // /
+	Percent          // %
+	StarStar         // **
+	Inc              // ++
+	Dec              // --
+	Xor              // ^
+	Not              // !
+	Tilde            // ~
+	Shl              // <<
+	Shr              // >>
+	UShr             // >>>
+	LogicalAnd       // &&
+	LogicalOr        // ||
+	Nullish          // ??
+	QuestionDot      // ?.
+	Eq               // ==
+	NotEq            // !=
+	StrictEq         // ===
+	StrictNotEq      // !==
+	LessEq           // <=
+	GreaterEq        // >=
+	PlusAssign       // +=
+	MinusAssign      // -=
+	StarAssign       // *=
+	SlashAssign      // /=
+	PercentAssign    // %=
+	StarStarAssign   // **=
+	ShlAssign        // <<=
+	ShrAssign        // >>=
+	UShrAssign       // >>>=
+	AndAssign        // &=
+	OrAssign         // |=
+	XorAssign        // ^=
+	LogicalAndAssign // &&=
+	LogicalOrAssign  // ||=
+	NullishAssign    // ??=
 
 	// Delimiters and punctuation.
 	LParen    // (
@@ -41,12 +92,93 @@ const (
 	Colon     // :
 	Semicolon // ;
 	Question  // ?
+	At        // @
+	Hash      // #
+	operatorEnd
+
+	keywordBeg
+	// Keywords.
+	Break      // break
+	Case       // case
+	Catch      // catch
+	Class      // class
+	Const      // const
+	Continue   // continue
+	Debugger   // debugger
+	Default    // default
+	Delete     // delete
+	Do         // do
+	Else       // else
+	Enum       // enum
+	Export     // export
+	Extends    // extends
+	False      // false
+	Finally    // finally
+	For        // for
+	Function   // function
+	If         // if
+	Import     // import
+	In         // in
+	Instanceof // instanceof
+	New        // new
+	Null       // null
+	Return     // return
+	Super      // super
+	Switch     // switch
+	This       // this
+	Throw      // throw
+	True       // true
+	Try        // try
+	Typeof     // typeof
+	Var        // var
+	Void       // void
+	While      // while
+	With       // with
+
+	contextualKeywordBeg
+	// Contextual keywords.
+	Abstract    // abstract
+	Accessor    // accessor
+	As          // as
+	Asserts     // asserts
+	Async       // async
+	Await       // await
+	Constructor // constructor
+	Declare     // declare
+	From        // from
+	Get         // get
+	Global      // global
+	Implements  // implements
+	Infer       // infer
+	Interface   // interface
+	Is          // is
+	Keyof       // keyof
+	Let         // let
+	Module      // module
+	Namespace   // namespace
+	Of          // of
+	Out         // out
+	Override    // override
+	Package     // package
+	Private     // private
+	Protected   // protected
+	Public      // public
+	Readonly    // readonly
+	Require     // require
+	Satisfies   // satisfies
+	Set         // set
+	Static      // static
+	Type        // type
+	Unique      // unique
+	Yield       // yield
+	keywordEnd
 )
 
 var tokens = [...]string{
 	// Special tokens.
-	Illegal: "Illegal",
-	EOF:     "EOF",
+	Illegal:    "Illegal",
+	EOF:        "EOF",
+	Whitespace: "Whitespace",
 
 	// Comments.
 	Comment:     "Comment",
@@ -55,12 +187,61 @@ var tokens = [...]string{
 	// Identifiers and literals.
 	Ident:  "Ident",
 	Number: "Number",
+	BigInt: "BigInt",
 	String: "String",
+	Regex:  "Regex",
+
+	// Template literals.
+	NoSubstitutionTemplate: "NoSubstitutionTemplate",
+	TemplateHead:           "TemplateHead",
+	TemplateMiddle:         "TemplateMiddle",
+	TemplateTail:           "TemplateTail",
 
 	// Operators.
-	Or:     "|",
-	Assign: "=",
-	Minus:  "-",
+	Or:               "|",
+	And:              "&",
+	Assign:           "=",
+	Minus:            "-",
+	Plus:             "+",
+	Arrow:            "=>",
+	Ellipsis:         "...",
+	Star:             "*",
+	Slash:            "/",
+	Percent:          "%",
+	StarStar:         "**",
+	Inc:              "++",
+	Dec:              "--",
+	Xor:              "^",
+	Not:              "!",
+	Tilde:            "~",
+	Shl:              "<<",
+	Shr:              ">>",
+	UShr:             ">>>",
+	LogicalAnd:       "&&",
+	LogicalOr:        "||",
+	Nullish:          "??",
+	QuestionDot:      "?.",
+	Eq:               "==",
+	NotEq:            "!=",
+	StrictEq:         "===",
+	StrictNotEq:      "!==",
+	LessEq:           "<=",
+	GreaterEq:        ">=",
+	PlusAssign:       "+=",
+	MinusAssign:      "-=",
+	StarAssign:       "*=",
+	SlashAssign:      "/=",
+	PercentAssign:    "%=",
+	StarStarAssign:   "**=",
+	ShlAssign:        "<<=",
+	ShrAssign:        ">>=",
+	UShrAssign:       ">>>=",
+	AndAssign:        "&=",
+	OrAssign:         "|=",
+	XorAssign:        "^=",
+	LogicalAndAssign: "&&=",
+	LogicalOrAssign:  "||=",
+	NullishAssign:    "??=",
 
 	// Delimiters and punctuation.
 	LParen:    "(",
@@ -76,6 +257,102 @@ var tokens = [...]string{
 	Colon:     ":",
 	Semicolon: ";",
 	Question:  "?",
+	At:        "@",
+	Hash:      "#",
+
+	// Keywords.
+	Break:      "break",
+	Case:       "case",
+	Catch:      "catch",
+	Class:      "class",
+	Const:      "const",
+	Continue:   "continue",
+	Debugger:   "debugger",
+	Default:    "default",
+	Delete:     "delete",
+	Do:         "do",
+	Else:       "else",
+	Enum:       "enum",
+	Export:     "export",
+	Extends:    "extends",
+	False:      "false",
+	Finally:    "finally",
+	For:        "for",
+	Function:   "function",
+	If:         "if",
+	Import:     "import",
+	In:         "in",
+	Instanceof: "instanceof",
+	New:        "new",
+	Null:       "null",
+	Return:     "return",
+	Super:      "super",
+	Switch:     "switch",
+	This:       "this",
+	Throw:      "throw",
+	True:       "true",
+	Try:        "try",
+	Typeof:     "typeof",
+	Var:        "var",
+	Void:       "void",
+	While:      "while",
+	With:       "with",
+
+	// Contextual keywords.
+	Abstract:    "abstract",
+	Accessor:    "accessor",
+	As:          "as",
+	Asserts:     "asserts",
+	Async:       "async",
+	Await:       "await",
+	Constructor: "constructor",
+	Declare:     "declare",
+	From:        "from",
+	Get:         "get",
+	Global:      "global",
+	Implements:  "implements",
+	Infer:       "infer",
+	Interface:   "interface",
+	Is:          "is",
+	Keyof:       "keyof",
+	Let:         "let",
+	Module:      "module",
+	Namespace:   "namespace",
+	Of:          "of",
+	Out:         "out",
+	Override:    "override",
+	Package:     "package",
+	Private:     "private",
+	Protected:   "protected",
+	Public:      "public",
+	Readonly:    "readonly",
+	Require:     "require",
+	Satisfies:   "satisfies",
+	Set:         "set",
+	Static:      "static",
+	Type:        "type",
+	Unique:      "unique",
+	Yield:       "yield",
+}
+
+var keywords map[string]Kind
+
+func init() {
+	keywords = make(map[string]Kind, keywordEnd-keywordBeg)
+	for k := keywordBeg + 1; k < keywordEnd; k++ {
+		if k != contextualKeywordBeg {
+			keywords[tokens[k]] = k
+		}
+	}
+}
+
+// Lookup maps an identifier to its keyword kind, or to [Ident] if the
+// identifier is not a keyword.
+func Lookup(ident string) Kind {
+	if kind, ok := keywords[ident]; ok {
+		return kind
+	}
+	return Ident
 }
 
 func (k Kind) String() string {
@@ -85,9 +362,65 @@ func (k Kind) String() string {
 	return "token(" + strconv.Itoa(int(k)) + ")"
 }
 
+// IsKeyword reports whether k is a keyword, including contextual keywords.
+func (k Kind) IsKeyword() bool {
+	return keywordBeg < k && k < keywordEnd && k != contextualKeywordBeg
+}
+
+// IsContextualKeyword reports whether k is a keyword that is only reserved in
+// certain contexts, and can otherwise be used as an identifier.
+func (k Kind) IsContextualKeyword() bool {
+	return contextualKeywordBeg < k && k < keywordEnd
+}
+
+// IsOperator reports whether k is an operator, delimiter or punctuation
+// token.
+func (k Kind) IsOperator() bool {
+	return operatorBeg < k && k < operatorEnd
+}
+
+// A set of constants for the precedence of binary operators. Higher values
+// bind more tightly.
+const (
+	LowestPrec  = 0 // non-operators
+	HighestPrec = 11
+)
+
+// Precedence returns the precedence of k as a binary operator, or
+// [LowestPrec] if k is not a binary operator.
+func (k Kind) Precedence() int {
+	switch k {
+	case LogicalOr, Nullish:
+		return 1
+	case LogicalAnd:
+		return 2
+	case Or:
+		return 3
+	case Xor:
+		return 4
+	case And:
+		return 5
+	case Eq, NotEq, StrictEq, StrictNotEq:
+		return 6
+	case LAngle, RAngle, LessEq, GreaterEq, Instanceof, In, As, Satisfies:
+		return 7
+	case Shl, Shr, UShr:
+		return 8
+	case Plus, Minus:
+		return 9
+	case Star, Slash, Percent:
+		return 10
+	case StarStar:
+		return 11
+	default:
+		return LowestPrec
+	}
+}
+
 func (k Kind) IsLiteral() bool {
 	switch k {
-	case Ident, Number, String, Comment, LineComment:
+	case Ident, Number, BigInt, String, Regex, Whitespace, Comment, LineComment,
+		NoSubstitutionTemplate, TemplateHead, TemplateMiddle, TemplateTail:
 		return true
 	default:
 		return false
@@ -99,4 +432,6 @@ func (k Kind) IsLiteral() bool {
 type Token struct {
 	Kind Kind
 	Text string
+	Pos  Pos // position of the first character of the token
+	End  Pos // position immediately after the last character of the token
 }
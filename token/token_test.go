@@ -0,0 +1,72 @@
+package token_test
+
+import (
+	"testing"
+
+	"github.com/armsnyder/typescript-ast-go/token"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		ident                       string
+		want                        token.Kind
+		wantKeyword, wantContextual bool
+	}{
+		{ident: "foo", want: token.Ident},
+		{ident: "string", want: token.Ident},
+		{ident: "class", want: token.Class, wantKeyword: true},
+		{ident: "extends", want: token.Extends, wantKeyword: true},
+		{ident: "typeof", want: token.Typeof, wantKeyword: true},
+		{ident: "type", want: token.Type, wantKeyword: true, wantContextual: true},
+		{ident: "readonly", want: token.Readonly, wantKeyword: true, wantContextual: true},
+		{ident: "yield", want: token.Yield, wantKeyword: true, wantContextual: true},
+		{ident: "Class", want: token.Ident},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ident, func(t *testing.T) {
+			got := token.Lookup(tt.ident)
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if got.IsKeyword() != tt.wantKeyword {
+				t.Errorf("got IsKeyword %v, want %v", got.IsKeyword(), tt.wantKeyword)
+			}
+			if got.IsContextualKeyword() != tt.wantContextual {
+				t.Errorf("got IsContextualKeyword %v, want %v", got.IsContextualKeyword(), tt.wantContextual)
+			}
+			if tt.wantKeyword && got.String() != tt.ident {
+				t.Errorf("got String %q, want %q", got.String(), tt.ident)
+			}
+		})
+	}
+}
+
+func TestKind_Precedence(t *testing.T) {
+	// Each group binds more tightly than the previous one.
+	groups := [][]token.Kind{
+		{token.Comma, token.Assign, token.Question, token.Arrow},
+		{token.LogicalOr, token.Nullish},
+		{token.LogicalAnd},
+		{token.Or},
+		{token.Xor},
+		{token.And},
+		{token.Eq, token.NotEq, token.StrictEq, token.StrictNotEq},
+		{token.LAngle, token.RAngle, token.LessEq, token.GreaterEq, token.Instanceof, token.In, token.As},
+		{token.Shl, token.Shr, token.UShr},
+		{token.Plus, token.Minus},
+		{token.Star, token.Slash, token.Percent},
+		{token.StarStar},
+	}
+
+	for i, group := range groups {
+		for _, kind := range group {
+			if got := kind.Precedence(); got != token.LowestPrec+i {
+				t.Errorf("%v: got precedence %d, want %d", kind, got, token.LowestPrec+i)
+			}
+		}
+	}
+	if len(groups)-1 != token.HighestPrec {
+		t.Errorf("got %d precedence levels, want HighestPrec %d", len(groups)-1, token.HighestPrec)
+	}
+}